@@ -0,0 +1,118 @@
+package day1
+
+import "unicode"
+
+// Aho–Corasick automaton over runes matching all words of a vocabulary in a
+// single pass, including overlapping matches.
+type automaton struct {
+	nodes      []acNode
+	ignoreCase bool
+}
+
+type acNode struct {
+	next map[rune]int
+	fail int // longest proper suffix of the node which is a node as well
+	word int // index of the word ending in the node, -1 if none
+	dict int // nearest node on the fail chain ending a word, -1 if none
+}
+
+// Word of the automaton together with its length in runes.
+type acWord struct {
+	Word
+	runes int
+}
+
+func (a *automaton) fold(r rune) rune {
+	if a.ignoreCase {
+		return unicode.ToLower(r)
+	}
+
+	return r
+}
+
+func (a *automaton) addNode() int {
+	a.nodes = append(a.nodes, acNode{next: make(map[rune]int), word: -1, dict: -1})
+
+	return len(a.nodes) - 1
+}
+
+// Builds the automaton of words. Words must not be empty. In case of
+// duplicate words, the last one wins.
+func newAutomaton(words []acWord, ignoreCase bool) *automaton {
+	ret := &automaton{ignoreCase: ignoreCase}
+	ret.addNode() // root
+
+	// trie of all words
+	for i, word := range words {
+		curr := 0
+		for _, r := range word.Text {
+			r = ret.fold(r)
+			next, ok := ret.nodes[curr].next[r]
+			if !ok {
+				next = ret.addNode()
+				ret.nodes[curr].next[r] = next
+			}
+			curr = next
+		}
+		ret.nodes[curr].word = i
+	}
+
+	// fail and dictionary links, breadth first so that links always point
+	// to nodes which were already processed
+	queue := []int{}
+	for _, child := range ret.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		for r, child := range ret.nodes[curr].next {
+			fail := ret.nodes[curr].fail
+			for fail > 0 {
+				if _, ok := ret.nodes[fail].next[r]; ok {
+					break
+				}
+				fail = ret.nodes[fail].fail
+			}
+			if next, ok := ret.nodes[fail].next[r]; ok && next != child {
+				fail = next
+			} else {
+				fail = 0
+			}
+			ret.nodes[child].fail = fail
+			if ret.nodes[fail].word >= 0 {
+				ret.nodes[child].dict = fail
+			} else {
+				ret.nodes[child].dict = ret.nodes[fail].dict
+			}
+			queue = append(queue, child)
+		}
+	}
+
+	return ret
+}
+
+// Returns the state after reading r in state.
+func (a *automaton) step(state int, r rune) int {
+	r = a.fold(r)
+	for {
+		if next, ok := a.nodes[state].next[r]; ok {
+			return next
+		}
+		if state == 0 {
+			return 0
+		}
+		state = a.nodes[state].fail
+	}
+}
+
+// Calls f with the index of every word ending in state, longest first.
+func (a *automaton) matches(state int, f func(word int)) {
+	if a.nodes[state].word < 0 {
+		state = a.nodes[state].dict
+	}
+	for state >= 0 {
+		f(a.nodes[state].word)
+		state = a.nodes[state].dict
+	}
+}
@@ -0,0 +1,45 @@
+package day1
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/prki/aoc2023/lib/logging"
+)
+
+var logger = logging.Day(1)
+
+// Returns the calibration value of a line considering numeric digits only.
+// Lines without digits have the value 0.
+func ProcessLine(calibrationLine string) int {
+	return Scanner{}.Scan(calibrationLine).Value()
+}
+
+// Sums calibration values of all lines, finding digits by scanner.
+// See CalibrateStream for huge inputs.
+func Calibrate(r io.Reader, scanner Scanner) (int, error) {
+	totals, err := CalibrateStream(r, scanner, StreamOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	return totals.Sum, nil
+}
+
+func Part1(r io.Reader) (string, error) {
+	ret, err := Calibrate(r, Scanner{})
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(ret), nil
+}
+
+func Part2(r io.Reader) (string, error) {
+	ret, err := Calibrate(r, NewScanner(EnglishWords, false))
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(ret), nil
+}
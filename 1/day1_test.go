@@ -0,0 +1,102 @@
+package day1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcessLine(t *testing.T) {
+	assert.Equal(t, 38, ProcessLine("pqr3stu8vwx"))
+	assert.Equal(t, 77, ProcessLine("treb7uchet"))
+	assert.Equal(t, 0, ProcessLine("nodigits"))
+}
+
+func TestScanner(t *testing.T) {
+	scanner := NewScanner(EnglishWords, false)
+
+	actual := scanner.Scan("xdzonexdzthree")
+	assert.Equal(t, Token{Text: "one", Value: 1, Offset: 3}, actual.First)
+	assert.Equal(t, Token{Text: "three", Value: 3, Offset: 9}, actual.Last)
+	assert.Equal(t, 13, actual.Value())
+
+	// overlapping words
+	actual = scanner.Scan("7pqrstsixteen1eightwo")
+	assert.Equal(t, Token{Text: "two", Value: 2, Offset: 18}, actual.Last)
+	assert.Equal(t, 72, actual.Value())
+
+	// invalid UTF-8 bytes are one byte wide
+	actual = scanner.Scan("\xffone\xfftwo")
+	assert.Equal(t, Token{Text: "one", Value: 1, Offset: 1}, actual.First)
+	assert.Equal(t, Token{Text: "two", Value: 2, Offset: 5}, actual.Last)
+	actual = NewScanner([]Word{{Text: "x\uFFFD", Value: 9}}, false).Scan("ax\xffb")
+	assert.Equal(t, Token{Text: "x\xff", Value: 9, Offset: 1}, actual.First)
+
+	actual = scanner.Scan("abc")
+	assert.False(t, actual.Found)
+	assert.Equal(t, 0, actual.Value())
+}
+
+func TestScannerVocabularies(t *testing.T) {
+	config := VocabularyConfig{
+		Vocabularies: []string{"en", "de", "cs-ordinal", "zero", "es"},
+		IgnoreCase:   true,
+		Custom:       map[string]map[string]int{"es": {"uno": 1, "dos": 2}},
+	}
+	scanner, err := config.Scanner()
+	assert.NoError(t, err)
+
+	actual := scanner.Scan("xxZEROxxfünfachtwoDOS")
+	assert.Equal(t, Token{Text: "ZERO", Value: 0, Offset: 2}, actual.First)
+	assert.Equal(t, Token{Text: "DOS", Value: 2, Offset: 19}, actual.Last)
+
+	// multi-byte runes with different lengths in lower and upper case
+	actual = scanner.Scan("ČTVRTÝxSEDMÝ")
+	assert.Equal(t, Token{Text: "ČTVRTÝ", Value: 4, Offset: 0}, actual.First)
+	assert.Equal(t, Token{Text: "SEDMÝ", Value: 7, Offset: 9}, actual.Last)
+
+	// longest word wins on the same offset
+	scanner = NewScanner(append(EnglishWords, Vocabularies["en-ordinal"]...), false)
+	actual = scanner.Scan("eighthree")
+	assert.Equal(t, Token{Text: "eighth", Value: 8, Offset: 0}, actual.First)
+	assert.Equal(t, Token{Text: "three", Value: 3, Offset: 4}, actual.Last)
+	actual = scanner.Scan("xseventh")
+	assert.Equal(t, Token{Text: "seventh", Value: 7, Offset: 1}, actual.Last)
+
+	_, err = VocabularyConfig{Vocabularies: []string{"xx"}}.Words()
+	assert.Error(t, err)
+}
+
+func TestHighlight(t *testing.T) {
+	scanner := NewScanner(EnglishWords, false)
+	assert.Equal(t, "[two]1<nine>", scanner.Scan("two1nine").Highlight(HighlightBrackets))
+	assert.Equal(t, "x[eigh<t]wo>", scanner.Scan("xeightwo").Highlight(HighlightBrackets))
+	assert.Equal(t, "treb[7]uchet", scanner.Scan("treb7uchet").Highlight(HighlightBrackets))
+	assert.Equal(t, "\x1b[1;32mtwo\x1b[0m1\x1b[1;34mnine\x1b[0m", scanner.Scan("two1nine").Highlight(HighlightANSI))
+
+	assert.Equal(t, `x[eigh<t]wo>: first "eight" at 1, last "two" at 5, value 82`, scanner.Scan("xeightwo").Explain(HighlightBrackets))
+	assert.Equal(t, "abc: no digits, value 00", scanner.Scan("abc").Explain(HighlightBrackets))
+}
+
+func TestCalibrateStream(t *testing.T) {
+	var doc strings.Builder
+	for i := 0; i < 5000; i++ {
+		doc.WriteString("two1nine\nxeightwo\nabc\n")
+	}
+
+	var progress []int
+	expected := Totals{Lines: 15000, Sum: 5000 * (29 + 82), NoDigits: 5000}
+	opts := StreamOptions{Progress: func(totals Totals) { progress = append(progress, totals.Lines) }, ProgressEvery: 4000}
+	actual, err := CalibrateStream(strings.NewReader(doc.String()), NewScanner(EnglishWords, false), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, []int{4000, 8000, 12000}, progress)
+
+	progress = nil
+	opts.Workers = 4
+	actual, err = CalibrateStream(strings.NewReader(doc.String()), NewScanner(EnglishWords, false), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	assert.Len(t, progress, 3)
+}
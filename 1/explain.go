@@ -0,0 +1,115 @@
+package day1
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/prki/aoc2023/lib/input"
+)
+
+// Way of marking the chosen tokens in explained lines.
+type Highlight int
+
+const (
+	// First token in [brackets], last token in <angle brackets>.
+	HighlightBrackets Highlight = iota
+	// First token green, last token blue, text of both tokens cyan.
+	HighlightANSI
+)
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiFirst = "\x1b[1;32m"
+	ansiLast  = "\x1b[1;34m"
+	ansiBoth  = "\x1b[1;36m"
+)
+
+// Returns the line with the first and the last token highlighted.
+// Tokens may overlap (e.g. "eightwo"), markers are nested accordingly.
+// A single token which is both first and last is marked as first.
+func (c Calibration) Highlight(h Highlight) string {
+	if !c.Found {
+		return c.Line
+	}
+
+	var ret strings.Builder
+	hasLast := c.Last != c.First
+	inFirst, inLast := false, false
+	for i := 0; i <= len(c.Line); i++ {
+		nextFirst := i >= c.First.Offset && i < c.First.End()
+		nextLast := hasLast && i >= c.Last.Offset && i < c.Last.End()
+		if nextFirst != inFirst || nextLast != inLast {
+			if h == HighlightANSI {
+				switch {
+				case nextFirst && nextLast:
+					ret.WriteString(ansiBoth)
+				case nextFirst:
+					ret.WriteString(ansiFirst)
+				case nextLast:
+					ret.WriteString(ansiLast)
+				default:
+					ret.WriteString(ansiReset)
+				}
+			} else {
+				if inFirst && !nextFirst {
+					ret.WriteByte(']')
+				}
+				if inLast && !nextLast {
+					ret.WriteByte('>')
+				}
+				if !inFirst && nextFirst {
+					ret.WriteByte('[')
+				}
+				if !inLast && nextLast {
+					ret.WriteByte('<')
+				}
+			}
+			inFirst, inLast = nextFirst, nextLast
+		}
+		if i < len(c.Line) {
+			ret.WriteByte(c.Line[i])
+		}
+	}
+
+	return ret.String()
+}
+
+// Returns a one line explanation of the calibration value, e.g.
+//
+//	[two]1<nine>: first "two" at 0, last "nine" at 4, value 29
+func (c Calibration) Explain(h Highlight) string {
+	if !c.Found {
+		return fmt.Sprintf("%s: no digits, value 00", c.Line)
+	}
+
+	return fmt.Sprintf("%s: first %q at %d, last %q at %d, value %02d",
+		c.Highlight(h), c.First.Text, c.First.Offset, c.Last.Text, c.Last.Offset, c.Value())
+}
+
+// Writes explanation of every line of r to w, followed by a summary of lines
+// without digits. Returns the sum of calibration values.
+func Explain(r io.Reader, w io.Writer, scanner Scanner, h Highlight) (int, error) {
+	var totals Totals
+	var noDigits []string
+
+	lines := input.NewScanner(r)
+	for lines.Scan() {
+		calibration := scanner.Scan(lines.Text())
+		totals.add(calibration)
+		if !calibration.Found {
+			noDigits = append(noDigits, fmt.Sprint(totals.Lines))
+		}
+		fmt.Fprintf(w, "line %d: %s\n", totals.Lines, calibration.Explain(h))
+	}
+	if err := lines.Err(); err != nil {
+		return totals.Sum, fmt.Errorf("line %d: %w", totals.Lines+1, err)
+	}
+
+	fmt.Fprintf(w, "%d lines, sum %d\n", totals.Lines, totals.Sum)
+	if len(noDigits) > 0 {
+		fmt.Fprintf(w, "%d lines without digits, counted as 00: %s\n", len(noDigits), strings.Join(noDigits, ", "))
+	}
+
+	return totals.Sum, nil
+}
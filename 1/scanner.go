@@ -0,0 +1,133 @@
+package day1
+
+import "unicode/utf8"
+
+// Digit found in a calibration line.
+type Token struct {
+	Text   string // matched text as found in the line, e.g. "7" or "Seven"
+	Value  int
+	Offset int // byte offset of Text in the line
+}
+
+// Returns the byte offset just after the token.
+func (t Token) End() int {
+	return t.Offset + len(t.Text)
+}
+
+// Result of scanning a calibration line.
+type Calibration struct {
+	Line  string
+	First Token
+	Last  Token
+	Found bool // false if the line contains no digit, First and Last are unset then
+}
+
+// Returns the two-digit calibration value, 0 for lines without digits.
+func (c Calibration) Value() int {
+	if !c.Found {
+		return 0
+	}
+
+	return c.First.Value*10 + c.Last.Value
+}
+
+// Finds digits in calibration lines. Numeric digits are always recognized,
+// spelled out digits only if they are in the vocabulary of the scanner.
+// The zero value recognizes numeric digits only.
+//
+// Lines are scanned in a single pass by an Aho–Corasick automaton which
+// reports overlapping matches as well, so words sharing letters
+// (e.g. "eightwo") are both found.
+type Scanner struct {
+	words []acWord
+	ac    *automaton
+}
+
+// Returns a scanner recognizing the given spelled out digits besides the
+// numeric ones. With ignoreCase set, words match regardless of case.
+func NewScanner(words []Word, ignoreCase bool) Scanner {
+	ret := Scanner{}
+	for _, word := range words {
+		if len(word.Text) == 0 {
+			continue
+		}
+		ret.words = append(ret.words, acWord{Word: word, runes: utf8.RuneCountInString(word.Text)})
+	}
+	if len(ret.words) > 0 {
+		ret.ac = newAutomaton(ret.words, ignoreCase)
+	}
+
+	return ret
+}
+
+// Returns whether token a should be preferred over b as the first digit.
+// Earlier tokens win, longer ones on a tie.
+func isBefore(a, b Token) bool {
+	return a.Offset < b.Offset || (a.Offset == b.Offset && len(a.Text) > len(b.Text))
+}
+
+// Returns whether token a should be preferred over b as the last digit.
+// Tokens starting later win, longer ones on a tie.
+func isAfter(a, b Token) bool {
+	return a.Offset > b.Offset || (a.Offset == b.Offset && len(a.Text) > len(b.Text))
+}
+
+// Finds the first and the last digit of line. The last digit is the one
+// starting at the greatest offset.
+func (s Scanner) Scan(line string) Calibration {
+	ret := Calibration{Line: line}
+
+	found := func(token Token) {
+		if !ret.Found {
+			ret.First, ret.Last, ret.Found = token, token, true
+			return
+		}
+		if isBefore(token, ret.First) {
+			ret.First = token
+		}
+		if isAfter(token, ret.Last) {
+			ret.Last = token
+		}
+	}
+
+	state := 0
+	for i, r := range line {
+		if r >= '0' && r <= '9' {
+			found(Token{Text: line[i : i+1], Value: int(r - '0'), Offset: i})
+		}
+		if s.ac == nil {
+			continue
+		}
+
+		// invalid bytes are decoded as RuneError of width 1
+		_, size := utf8.DecodeRuneInString(line[i:])
+		end := i + size
+		state = s.ac.step(state, r)
+		s.ac.matches(state, func(idx int) {
+			// walk back over the runes of the word, the matched text may
+			// differ in case and thus in byte length from the word
+			start := end
+			for n := 0; n < s.words[idx].runes; n++ {
+				_, size := utf8.DecodeLastRuneInString(line[:start])
+				start -= size
+			}
+			found(Token{Text: line[start:end], Value: s.words[idx].Value, Offset: start})
+		})
+	}
+
+	return ret
+}
+
+// Returns the first digit of line.
+func (s Scanner) First(line string) (Token, bool) {
+	ret := s.Scan(line)
+
+	return ret.First, ret.Found
+}
+
+// Returns the last digit of line.
+func (s Scanner) Last(line string) (Token, bool) {
+	ret := s.Scan(line)
+
+	return ret.Last, ret.Found
+}
@@ -0,0 +1,152 @@
+package day1
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/prki/aoc2023/lib/input"
+)
+
+// Number of lines handed to a worker at once.
+const batchSize = 1024
+
+// Running totals of a calibration.
+type Totals struct {
+	Lines    int
+	Sum      int
+	NoDigits int // lines without digits, which count as 00
+}
+
+func (t *Totals) add(calibration Calibration) {
+	t.Lines += 1
+	t.Sum += calibration.Value()
+	if !calibration.Found {
+		t.NoDigits += 1
+	}
+}
+
+func (t *Totals) merge(other Totals) {
+	t.Lines += other.Lines
+	t.Sum += other.Sum
+	t.NoDigits += other.NoDigits
+}
+
+// Options of CalibrateStream.
+type StreamOptions struct {
+	// Number of goroutines scanning lines. With 0 or 1, lines are scanned by
+	// the calling goroutine.
+	Workers int
+	// Called with the running totals after roughly every ProgressEvery lines,
+	// if both are set. Always called from the calling goroutine.
+	Progress      func(Totals)
+	ProgressEvery int
+}
+
+// Reports progress once the totals pass the next reporting point.
+type progress struct {
+	StreamOptions
+	next int
+}
+
+func (p *progress) report(totals Totals) {
+	if p.Progress == nil || p.ProgressEvery <= 0 {
+		return
+	}
+	if p.next == 0 {
+		p.next = p.ProgressEvery
+	}
+	if totals.Lines >= p.next {
+		p.Progress(totals)
+		p.next = (totals.Lines/p.ProgressEvery + 1) * p.ProgressEvery
+	}
+}
+
+// Sums calibration values of all lines of r, finding digits by scanner.
+// Lines are read one at a time, so memory use doesn't depend on the size
+// of the input, even with workers.
+func CalibrateStream(r io.Reader, scanner Scanner, opts StreamOptions) (Totals, error) {
+	var ret Totals
+	var err error
+
+	prog := &progress{StreamOptions: opts}
+	if opts.Workers <= 1 {
+		ret, err = calibrateSequential(r, scanner, prog)
+	} else {
+		ret, err = calibrateParallel(r, scanner, prog)
+	}
+	if err != nil {
+		return ret, fmt.Errorf("line %d: %w", ret.Lines+1, err)
+	}
+
+	return ret, nil
+}
+
+func calibrateSequential(r io.Reader, scanner Scanner, prog *progress) (Totals, error) {
+	var ret Totals
+
+	lines := input.NewScanner(r)
+	for lines.Scan() {
+		calibration := scanner.Scan(lines.Text())
+		logger.Debug("line calibrated", "line", calibration.Line, "first", calibration.First.Text, "last", calibration.Last.Text, "value", calibration.Value())
+		ret.add(calibration)
+		prog.report(ret)
+	}
+
+	return ret, lines.Err()
+}
+
+// Reads batches of lines and fans them out to the workers. The number of
+// batches in flight is bounded by the channel capacities.
+func calibrateParallel(r io.Reader, scanner Scanner, prog *progress) (Totals, error) {
+	var ret Totals
+
+	batches := make(chan []string, prog.Workers)
+	results := make(chan Totals, prog.Workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < prog.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				var totals Totals
+				for _, line := range batch {
+					totals.add(scanner.Scan(line))
+				}
+				results <- totals
+			}
+		}()
+	}
+
+	var readErr error
+	go func() {
+		defer close(batches)
+		lines := input.NewScanner(r)
+		batch := make([]string, 0, batchSize)
+		for lines.Scan() {
+			batch = append(batch, lines.Text())
+			if len(batch) == batchSize {
+				batches <- batch
+				batch = make([]string, 0, batchSize)
+			}
+		}
+		if len(batch) > 0 {
+			batches <- batch
+		}
+		readErr = lines.Err()
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for totals := range results {
+		ret.merge(totals)
+		prog.report(ret)
+	}
+
+	// all workers are done, so the reader is done as well
+	return ret, readErr
+}
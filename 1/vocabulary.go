@@ -0,0 +1,147 @@
+package day1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Spelled out digit.
+type Word struct {
+	Text  string
+	Value int
+}
+
+// Spelled out digits of part 2. Zero is not part of the puzzle.
+var EnglishWords = []Word{
+	{"one", 1}, {"two", 2}, {"three", 3}, {"four", 4}, {"five", 5},
+	{"six", 6}, {"seven", 7}, {"eight", 8}, {"nine", 9},
+}
+
+// Built-in vocabularies by name.
+var Vocabularies = map[string][]Word{
+	"en": EnglishWords,
+	"de": {
+		{"eins", 1}, {"zwei", 2}, {"drei", 3}, {"vier", 4}, {"fünf", 5},
+		{"sechs", 6}, {"sieben", 7}, {"acht", 8}, {"neun", 9},
+	},
+	"cs": {
+		{"jedna", 1}, {"dva", 2}, {"tři", 3}, {"čtyři", 4}, {"pět", 5},
+		{"šest", 6}, {"sedm", 7}, {"osm", 8}, {"devět", 9},
+	},
+	"en-ordinal": {
+		{"first", 1}, {"second", 2}, {"third", 3}, {"fourth", 4}, {"fifth", 5},
+		{"sixth", 6}, {"seventh", 7}, {"eighth", 8}, {"ninth", 9},
+	},
+	"de-ordinal": {
+		{"erste", 1}, {"zweite", 2}, {"dritte", 3}, {"vierte", 4}, {"fünfte", 5},
+		{"sechste", 6}, {"siebte", 7}, {"achte", 8}, {"neunte", 9},
+	},
+	"cs-ordinal": {
+		{"první", 1}, {"druhý", 2}, {"třetí", 3}, {"čtvrtý", 4}, {"pátý", 5},
+		{"šestý", 6}, {"sedmý", 7}, {"osmý", 8}, {"devátý", 9},
+	},
+	// zero in all of the languages above
+	"zero": {{"zero", 0}, {"null", 0}, {"nula", 0}},
+}
+
+// Returns names of the built-in vocabularies, sorted.
+func VocabularyNames() []string {
+	ret := make([]string, 0, len(Vocabularies))
+	for name := range Vocabularies {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+
+	return ret
+}
+
+// Configuration of the spelled out digits recognized by the scanner, e.g.:
+//
+//	vocabularies = ["en", "de", "zero", "es"]
+//	ignore_case = true
+//
+//	[custom.es]
+//	uno = 1
+//	dos = 2
+type VocabularyConfig struct {
+	// Names of built-in or custom vocabularies to be used.
+	Vocabularies []string `toml:"vocabularies"`
+	IgnoreCase   bool     `toml:"ignore_case"`
+	// Custom vocabularies by name, mapping words to their values.
+	Custom map[string]map[string]int `toml:"custom"`
+}
+
+// Loads the vocabulary configuration in the TOML file on path.
+func LoadVocabularyConfig(path string) (VocabularyConfig, error) {
+	var ret VocabularyConfig
+
+	meta, err := toml.DecodeFile(path, &ret)
+	if err != nil {
+		return ret, fmt.Errorf("can't load vocabularies %s: %w", path, err)
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return ret, fmt.Errorf("unknown keys in vocabularies %s: %v", path, undecoded)
+	}
+
+	return ret, nil
+}
+
+// Parses a comma separated list of vocabulary names, e.g. "en,de-ordinal".
+func ParseVocabularyNames(s string) []string {
+	var ret []string
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if len(name) > 0 {
+			ret = append(ret, name)
+		}
+	}
+
+	return ret
+}
+
+// Returns words of all configured vocabularies. Custom vocabularies take
+// precedence over built-in ones of the same name.
+func (c VocabularyConfig) Words() ([]Word, error) {
+	var ret []Word
+
+	for _, name := range c.Vocabularies {
+		if custom, ok := c.Custom[name]; ok {
+			texts := make([]string, 0, len(custom))
+			for text := range custom {
+				texts = append(texts, text)
+			}
+			sort.Strings(texts)
+			for _, text := range texts {
+				if len(text) == 0 {
+					return nil, fmt.Errorf("vocabulary %s: empty word", name)
+				}
+				if value := custom[text]; value < 0 || value > 9 {
+					return nil, fmt.Errorf("vocabulary %s: value of %q is not a digit: %d", name, text, value)
+				}
+				ret = append(ret, Word{Text: text, Value: custom[text]})
+			}
+			continue
+		}
+
+		words, ok := Vocabularies[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown vocabulary %q, known vocabularies: %s", name, strings.Join(VocabularyNames(), ", "))
+		}
+		ret = append(ret, words...)
+	}
+
+	return ret, nil
+}
+
+// Returns a scanner recognizing words of the configured vocabularies.
+func (c VocabularyConfig) Scanner() (Scanner, error) {
+	words, err := c.Words()
+	if err != nil {
+		return Scanner{}, err
+	}
+
+	return NewScanner(words, c.IgnoreCase), nil
+}
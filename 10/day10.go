@@ -0,0 +1,278 @@
+package day10
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/prki/aoc2023/lib/grid"
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/prki/aoc2023/lib/logging"
+)
+
+var logger = logging.Day(10)
+
+type PipeType byte
+
+const (
+	VERTICAL   PipeType = '|'
+	HORIZONTAL          = '-'
+	NORTHEAST           = 'L'
+	NORTHWEST           = 'J'
+	SOUTHWEST           = '7'
+	SOUTHEAST           = 'F'
+	START               = 'S'
+)
+
+// Naive quick implementation pretty printing a PipeType
+func (pt *PipeType) PTReadable() string {
+	switch *pt {
+	case VERTICAL:
+		return "VERTICAL"
+	case HORIZONTAL:
+		return "HORIZONTAL"
+	case NORTHEAST:
+		return "NORTHEAST"
+	case NORTHWEST:
+		return "NORTHWEST"
+	case SOUTHWEST:
+		return "SOUTHWEST"
+	case SOUTHEAST:
+		return "SOUTHEAST"
+	case START:
+		return "START"
+	}
+	logger.Warn("unable to pretty print pipe type", "type", string(*pt), "byte", byte(*pt))
+	return "UNKNOWN"
+}
+
+type Node struct {
+	Neighbors []*Node
+	Type      PipeType
+	Coords    grid.Point // unique ID of the node
+}
+
+// Prints the node and its neighbors to w, e.g. os.Stderr.
+func (n *Node) PrettyPrint(w io.Writer) {
+	fmt.Fprintln(w, "Node coords: X:", n.Coords.X, "Y:", n.Coords.Y, "type:", n.Type.PTReadable())
+	fmt.Fprintln(w, "Neighbors on coords:")
+	for i := 0; i < len(n.Neighbors); i++ {
+		fmt.Fprintln(w, *n.Neighbors[i])
+	}
+}
+
+// To consider - the problem requires finding a strongly connected component
+// of the graph where the animal is supposedly stuck. For now, I won't implement
+// that, but who knows.
+type Graph struct {
+	Nodes []*Node
+	Tiles *grid.Grid[*Node] // nil on tiles without a pipe
+}
+
+// Returns the node on p, if there is one.
+func (g *Graph) Node(p grid.Point) (*Node, bool) {
+	ret := g.Tiles.Get(p)
+
+	return ret, ret != nil
+}
+
+func (n *Node) AddNeighbors(graph *Graph, crd1, crd2 grid.Point) {
+	neighbor1, ok := graph.Node(crd1)
+	if !ok {
+		logger.Warn("unable to get neighbor nodes", "coords", n.Coords, "neighbor", crd1)
+		return
+	}
+	neighbor2, ok := graph.Node(crd2)
+	if !ok {
+		logger.Warn("unable to get neighbor nodes", "coords", n.Coords, "neighbor", crd2)
+		return
+	}
+
+	n.Neighbors = append(n.Neighbors, neighbor1, neighbor2)
+}
+
+// Utility function calculating to-be neighbor coords based on a specific position
+// and a pipe type.
+func CalcNeighborCoords(pos grid.Point, pt PipeType) (grid.Point, grid.Point) {
+	if pt == VERTICAL {
+		return pos.Add(grid.North), pos.Add(grid.South)
+	} else if pt == HORIZONTAL {
+		return pos.Add(grid.East), pos.Add(grid.West)
+	} else if pt == NORTHEAST {
+		return pos.Add(grid.North), pos.Add(grid.East)
+	} else if pt == NORTHWEST {
+		return pos.Add(grid.North), pos.Add(grid.West)
+	} else if pt == SOUTHEAST {
+		return pos.Add(grid.South), pos.Add(grid.East)
+	} else if pt == SOUTHWEST {
+		return pos.Add(grid.South), pos.Add(grid.West)
+	} else if pt == START {
+		// nothing
+	} else {
+		log.Fatal("Unhandled node type when adding neighbors")
+	}
+
+	return grid.Point{}, grid.Point{} // should be unreachable
+}
+
+func (n *Node) HasNeighbor(other *Node) bool {
+	for i := 0; i < len(n.Neighbors); i++ {
+		if n.Neighbors[i].Coords.X == other.Coords.X && n.Neighbors[i].Coords.Y == other.Coords.Y {
+			return true
+		}
+	}
+	return false
+}
+
+// Adds neighbors to start node. tries each option and checks whether 2 neighbors are present.
+// If so, that particular option is the "true" node type.
+func CalcStartNeighbors(graph *Graph, startNode *Node) {
+	pipeTypes := []PipeType{VERTICAL, HORIZONTAL, NORTHEAST, NORTHWEST, SOUTHWEST, SOUTHEAST}
+	for i := 0; i < len(pipeTypes); i++ {
+		coord1, coord2 := CalcNeighborCoords(startNode.Coords, pipeTypes[i])
+		node1, ok1 := graph.Node(coord1)
+		node2, ok2 := graph.Node(coord2)
+		if ok1 && ok2 {
+			logger.Debug("testing start node neighbors", "type", pipeTypes[i].PTReadable(), "node1", node1.Coords, "node2", node2.Coords)
+			if node1.HasNeighbor(startNode) && node2.HasNeighbor(startNode) {
+				startNode.Neighbors = append(startNode.Neighbors, node1, node2)
+				return
+			}
+		}
+	}
+}
+
+// Returns a parse error pointing to the tile on p.
+func tileError(tiles *grid.Grid[rune], p grid.Point, format string, args ...any) *input.ParseError {
+	var row strings.Builder
+	tiles.Row(p.Y)(func(_ grid.Point, r rune) bool {
+		row.WriteRune(r)
+		return true
+	})
+
+	return &input.ParseError{
+		Line:   p.Y + 1,
+		Column: len(string([]rune(row.String())[:p.X])) + 1,
+		Input:  row.String(),
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+// Graph construction is done in a two-pass algorithm.
+// In the first pass, we traverse the input to discover nodes and simply
+// add them into the graph and its tiles. In the second pass, we traverse
+// the constructed nodes and based on their type and coord, we add the
+// possible edges (implemented as neighbor node pointer(s)).
+// There must be exactly one start tile linked to two neighbors, errors are
+// of type *input.ParseError.
+func ConstructGraph(tiles *grid.Grid[rune]) (*Graph, *Node, error) {
+	ret := &Graph{
+		Nodes: []*Node{},
+		Tiles: grid.New[*Node](tiles.Width(), tiles.Height()),
+	}
+
+	// First pass - create nodes
+	tiles.All()(func(coord grid.Point, tile rune) bool {
+		if tile == '.' {
+			return true
+		}
+		node := Node{
+			//Neighbors: []*Node{},
+			Neighbors: make([]*Node, 0, 2),
+			Coords:    coord,
+			Type:      PipeType(tile),
+		}
+		ret.Nodes = append(ret.Nodes, &node)
+		ret.Tiles.Set(coord, &node)
+		return true
+	})
+
+	// Second pass - assign neighbor nodes/edges
+	var startNode *Node // neighbors defined only after all neighbors are set
+	pipeTypes := []PipeType{VERTICAL, HORIZONTAL, NORTHEAST, NORTHWEST, SOUTHWEST, SOUTHEAST}
+	for i := 0; i < len(ret.Nodes); i++ {
+		currNode := ret.Nodes[i]
+		if currNode.Type == START {
+			if startNode != nil {
+				return nil, nil, tileError(tiles, currNode.Coords, "more than one start tile, first at line %d, column %d", startNode.Coords.Y+1, startNode.Coords.X+1)
+			}
+			startNode = currNode
+		} else {
+			for j := 0; j < len(pipeTypes); j++ {
+				if currNode.Type == pipeTypes[j] {
+					neigh1, neigh2 := CalcNeighborCoords(currNode.Coords, pipeTypes[j])
+					logging.Trace(logger, "adding neighbors", "coords", currNode.Coords, "neighbor1", neigh1, "neighbor2", neigh2)
+					currNode.AddNeighbors(ret, neigh1, neigh2)
+				}
+			}
+		}
+	}
+
+	if startNode == nil {
+		return nil, nil, &input.ParseError{Reason: "no start tile 'S'"}
+	}
+	CalcStartNeighbors(ret, startNode)
+	if len(startNode.Neighbors) < 2 {
+		return nil, nil, tileError(tiles, startNode.Coords, "start tile is not linked to two pipes")
+	}
+
+	return ret, startNode, nil
+}
+
+// Fills distMap with distances of all nodes reachable from start and returns
+// the greatest distance.
+func BFSDistanceMap(graph *Graph, start *Node, distMap map[grid.Point]int) int {
+	logger.Debug("building BFS distance map", "nodes", len(graph.Nodes), "start", start.Coords)
+	visited := grid.New[bool](graph.Tiles.Width(), graph.Tiles.Height())
+	queue := []*Node{}
+	queue = append(queue, start)
+	visited.Set(start.Coords, true)
+	distMap[start.Coords] = 0
+	maxDist := 0
+	for len(queue) > 0 {
+		currNode := queue[0]
+		queue = queue[1:] // dequeue
+		logging.Trace(logger, "BFS dequeued node", "coords", currNode.Coords)
+		for i := 0; i < len(currNode.Neighbors); i++ {
+			if !visited.Get(currNode.Neighbors[i].Coords) {
+				queue = append(queue, currNode.Neighbors[i])
+				distMap[currNode.Neighbors[i].Coords] = distMap[currNode.Coords] + 1
+				if distMap[currNode.Coords]+1 > maxDist {
+					maxDist = distMap[currNode.Coords] + 1
+				}
+				visited.Set(currNode.Neighbors[i].Coords, true)
+				logging.Trace(logger, "BFS enqueued node", "coords", currNode.Neighbors[i].Coords, "distance", distMap[currNode.Neighbors[i].Coords])
+			}
+		}
+	}
+
+	return maxDist
+}
+
+func Solution1(graph *Graph, start *Node) int {
+	distanceMap := make(map[grid.Point]int)
+	maxDist := BFSDistanceMap(graph, start, distanceMap)
+	logger.Debug("distance map built", "nodes", len(distanceMap), "maxDist", maxDist)
+
+	return maxDist
+}
+
+// Only part 1 was implemented, see README.md.
+func Part1(r io.Reader) (string, error) {
+	lines, err := input.ReadLines(r)
+	if err != nil {
+		return "", err
+	}
+	tiles, err := grid.ParseRunes(lines)
+	if err != nil {
+		return "", err
+	}
+	graph, startNode, err := ConstructGraph(tiles)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(Solution1(graph, startNode)), nil
+}
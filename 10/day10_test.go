@@ -0,0 +1,28 @@
+package day10
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPart1StartErrors(t *testing.T) {
+	answer, err := Part1(strings.NewReader(".....\n.S-7.\n.|.|.\n.L-J.\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "4", answer)
+
+	for in, expected := range map[string]input.ParseError{
+		"":                             {Reason: "no start tile 'S'"},
+		"foo bar\n":                    {Reason: "no start tile 'S'"},
+		"S-S\n":                        {Line: 1, Column: 3, Input: "S-S", Reason: "more than one start tile, first at line 1, column 1"},
+		".....\n.S.7.\n.|.|.\n.L-J.\n": {Line: 2, Column: 2, Input: ".S.7.", Reason: "start tile is not linked to two pipes"},
+	} {
+		_, err := Part1(strings.NewReader(in))
+		var perr *input.ParseError
+		if assert.ErrorAs(t, err, &perr, in) {
+			assert.Equal(t, expected, *perr, in)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package day2
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Bag of part 1 of the puzzle.
+var PuzzleBag = CubeGameConfiguration{"red": 12, "green": 13, "blue": 14}
+
+// Candidate bag configuration. Name identifies the bag in reports.
+type Bag struct {
+	Name  string                `json:"name" yaml:"name"`
+	Cubes CubeGameConfiguration `json:"cubes" yaml:"cubes"`
+}
+
+// Returns the configuration as "color=count" items sorted by color, e.g.
+// "blue=14,green=13,red=12".
+func (cfg CubeGameConfiguration) String() string {
+	colors := make([]string, 0, len(cfg))
+	for color := range cfg {
+		colors = append(colors, color)
+	}
+	sort.Strings(colors)
+
+	items := make([]string, len(colors))
+	for i, color := range colors {
+		items[i] = color + "=" + strconv.Itoa(cfg[color])
+	}
+
+	return strings.Join(items, ",")
+}
+
+// Parses a bag specification of comma separated "color=count" items,
+// e.g. "red=12,green=13,blue=14".
+func ParseBag(spec string) (CubeGameConfiguration, error) {
+	ret := make(CubeGameConfiguration)
+
+	for _, item := range strings.Split(spec, ",") {
+		color, countStr, ok := strings.Cut(item, "=")
+		color = strings.TrimSpace(color)
+		if !ok || len(color) == 0 {
+			return nil, fmt.Errorf("invalid bag item %q, expected \"<color>=<count>\"", item)
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(countStr))
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("invalid cube count in bag item %q", item)
+		}
+		if _, ok := ret[color]; ok {
+			return nil, fmt.Errorf("duplicate color %q in bag", color)
+		}
+		ret[color] = count
+	}
+
+	return ret, nil
+}
+
+// Loads a list of candidate bags from a JSON or YAML file, depending on
+// the extension of path, e.g.:
+//
+//   - name: puzzle
+//     cubes: {red: 12, green: 13, blue: 14}
+//
+// Bags without a name are named after their configuration.
+func LoadBags(path string) ([]Bag, error) {
+	var ret []Bag
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't load bags: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, &ret)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &ret)
+	default:
+		return nil, fmt.Errorf("can't load bags %s: unknown format, expected .json, .yaml or .yml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't load bags %s: %w", path, err)
+	}
+
+	for i := range ret {
+		for color, count := range ret[i].Cubes {
+			if count < 0 {
+				return nil, fmt.Errorf("bags %s: negative count of %s cubes in bag %d", path, color, i+1)
+			}
+		}
+		if len(ret[i].Name) == 0 {
+			ret[i].Name = ret[i].Cubes.String()
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,254 @@
+package day2
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prki/aoc2023/lib/input"
+)
+
+// Number of cubes of each color revealed in a round, keyed by color name.
+// Colors which were not revealed are missing.
+type CubeGameRound map[string]int
+
+type CubeGame struct {
+	Id     int
+	Rounds []CubeGameRound
+}
+
+// Maximum number of cubes of each color in the bag, keyed by color name.
+// Colors which are missing are not in the bag at all.
+type CubeGameConfiguration map[string]int
+
+// Parses a single "Game <id>: <count> <color>, ...; ..." line, see Parser.
+// All problems found in the line are returned as an input.ErrorList.
+func ParseInputLine(inputLine string) (CubeGame, error) {
+	return NewParser(inputLine).ParseGame()
+}
+
+// Returns whether every round of the game is possible with the bag
+// configuration, i.e. no round reveals more cubes of any color than the bag
+// holds. See ValidateGameReport for the reasons of impossible games.
+func ValidateGame(game CubeGame, cfg CubeGameConfiguration) bool {
+	for _, rnd := range game.Rounds {
+		for color, count := range rnd {
+			if count > cfg[color] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Round of a game revealing more cubes of a color than the bag holds.
+type Violation struct {
+	Round    int // 1-based index of the round in the game
+	Color    string
+	Observed int
+	Max      int // cubes of the color in the bag
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("round %d: %d %s, bag holds %d", v.Round, v.Observed, v.Color, v.Max)
+}
+
+// Result of validating a game against a bag configuration.
+type ValidationReport struct {
+	GameId     int
+	Violations []Violation // ordered by round and color
+}
+
+func (r ValidationReport) Possible() bool {
+	return len(r.Violations) == 0
+}
+
+func (r ValidationReport) String() string {
+	if r.Possible() {
+		return fmt.Sprintf("Game %d: possible", r.GameId)
+	}
+
+	reasons := make([]string, len(r.Violations))
+	for i, v := range r.Violations {
+		reasons[i] = v.String()
+	}
+
+	return fmt.Sprintf("Game %d: %s", r.GameId, strings.Join(reasons, "; "))
+}
+
+// Validates the game against the bag configuration, reporting every round
+// and color which makes the game impossible.
+func ValidateGameReport(game CubeGame, cfg CubeGameConfiguration) ValidationReport {
+	ret := ValidationReport{GameId: game.Id}
+
+	for i, rnd := range game.Rounds {
+		colors := make([]string, 0, len(rnd))
+		for color := range rnd {
+			colors = append(colors, color)
+		}
+		sort.Strings(colors)
+
+		for _, color := range colors {
+			if rnd[color] > cfg[color] {
+				ret.Violations = append(ret.Violations, Violation{
+					Round:    i + 1,
+					Color:    color,
+					Observed: rnd[color],
+					Max:      cfg[color],
+				})
+			}
+		}
+	}
+
+	return ret
+}
+
+// Returns reports of all games which are impossible with the bag
+// configuration.
+func ImpossibleGames(games []CubeGame, cfg CubeGameConfiguration) []ValidationReport {
+	var ret []ValidationReport
+	for _, game := range games {
+		report := ValidateGameReport(game, cfg)
+		if !report.Possible() {
+			ret = append(ret, report)
+		}
+	}
+
+	return ret
+}
+
+// Returns the smallest bag configuration with which the game is possible.
+func CalcMinimumConfiguration(game CubeGame) CubeGameConfiguration {
+	cfg := make(CubeGameConfiguration)
+
+	for _, rnd := range game.Rounds {
+		for color, count := range rnd {
+			if count > cfg[color] {
+				cfg[color] = count
+			}
+		}
+	}
+
+	return cfg
+}
+
+// Returns the product of cube counts of the given colors. Colors missing in
+// the configuration count as zero.
+func (cfg CubeGameConfiguration) Power(colors []string) int {
+	ret := 1
+	for _, color := range colors {
+		ret *= cfg[color]
+	}
+
+	return ret
+}
+
+// Returns all colors revealed in any round of the games, sorted.
+func Colors(games []CubeGame) []string {
+	seen := make(map[string]bool)
+	for _, game := range games {
+		for _, rnd := range game.Rounds {
+			for color := range rnd {
+				seen[color] = true
+			}
+		}
+	}
+
+	ret := make([]string, 0, len(seen))
+	for color := range seen {
+		ret = append(ret, color)
+	}
+	sort.Strings(ret)
+
+	return ret
+}
+
+// Sums IDs of the games which are possible with the bag configuration.
+func SumPossibleIds(games []CubeGame, cfg CubeGameConfiguration) int {
+	var validCubeGames []CubeGame
+	for _, game := range games {
+		if ValidateGame(game, cfg) {
+			validCubeGames = append(validCubeGames, game)
+		}
+	}
+
+	return SumValidIds(validCubeGames)
+}
+
+// Sums powers of the smallest possible configuration of each game. Power is
+// the product over all colors of the games, so a game missing any of them
+// has power zero.
+func SumPowers(games []CubeGame) int {
+	colors := Colors(games)
+	ret := 0
+	for _, game := range games {
+		smallestCfg := CalcMinimumConfiguration(game)
+		ret += smallestCfg.Power(colors)
+	}
+
+	return ret
+}
+
+func SumValidIds(games []CubeGame) int {
+	ret := 0
+
+	for _, game := range games {
+		ret += game.Id
+	}
+
+	return ret
+}
+
+// Parses all games of the input. Blank lines are skipped. Games which could
+// not be parsed are left out and all their errors are returned together.
+func ParseGames(lines []string) ([]CubeGame, error) {
+	var cubeGames []CubeGame
+	var errs input.ErrorList
+
+	for i, lin := range lines {
+		if len(strings.TrimSpace(lin)) == 0 {
+			continue
+		}
+		game, err := ParseInputLine(lin)
+		if err != nil {
+			errs.AddLine(i+1, lin, err)
+			continue
+		}
+		cubeGames = append(cubeGames, game)
+	}
+
+	return cubeGames, errs.Err()
+}
+
+// Reads and parses all games of r.
+func ReadGames(r io.Reader) ([]CubeGame, error) {
+	lines, err := input.ReadLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseGames(lines)
+}
+
+// Sums IDs of games which are possible with PuzzleBag.
+func Part1(r io.Reader) (string, error) {
+	cubeGames, err := ReadGames(r)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(SumPossibleIds(cubeGames, PuzzleBag)), nil
+}
+
+// Sums powers of the smallest possible configuration of each game.
+func Part2(r io.Reader) (string, error) {
+	cubeGames, err := ReadGames(r)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(SumPowers(cubeGames)), nil
+}
@@ -0,0 +1,155 @@
+package day2
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLine(t *testing.T) {
+	tin := "Game 5: 2 red, 3 blue; 4 green, 10 red, 20 blue"
+	var expectedRnds []CubeGameRound = []CubeGameRound{
+		{"red": 2, "blue": 3},
+		{"red": 10, "green": 4, "blue": 20},
+	}
+	expectedGame := CubeGame{
+		Id:     5,
+		Rounds: expectedRnds,
+	}
+	actual, err := ParseInputLine(tin)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedGame, actual)
+}
+
+func TestParseGamesErrors(t *testing.T) {
+	lines := []string{
+		"Game 1: 2 red, x blue",
+		"Game 2: 3; 4",
+		"",
+	}
+	_, err := ParseGames(lines)
+	var errs input.ErrorList
+	assert.ErrorAs(t, err, &errs)
+	assert.Len(t, errs, 3)
+	assert.Equal(t, input.ParseError{Line: 1, Column: 16, Input: lines[0], Reason: `invalid cube count "x"`}, *errs[0])
+	assert.Equal(t, input.ParseError{Line: 2, Column: 10, Input: lines[1], Reason: `expected cube color, got ";"`}, *errs[1])
+	assert.Equal(t, input.ParseError{Line: 2, Column: 13, Input: lines[1], Reason: `expected cube color, got end of line`}, *errs[2])
+}
+
+func TestArbitraryColors(t *testing.T) {
+	games, err := ParseGames([]string{
+		"Game 1: 3 yellow, 1 purple; 2 yellow",
+		"Game 2: 5 purple",
+	})
+	assert.NoError(t, err)
+
+	cfg := CubeGameConfiguration{"yellow": 3, "purple": 4}
+	assert.True(t, ValidateGame(games[0], cfg))
+	assert.False(t, ValidateGame(games[1], cfg))
+
+	colors := Colors(games)
+	assert.Equal(t, []string{"purple", "yellow"}, colors)
+	assert.Equal(t, CubeGameConfiguration{"yellow": 3, "purple": 1}, CalcMinimumConfiguration(games[0]))
+	assert.Equal(t, 3, CalcMinimumConfiguration(games[0]).Power(colors))
+	assert.Equal(t, 0, CalcMinimumConfiguration(games[1]).Power(colors))
+}
+
+func TestParseBag(t *testing.T) {
+	cfg, err := ParseBag("red=12, green=13,blue = 14")
+	assert.NoError(t, err)
+	assert.Equal(t, PuzzleBag, cfg)
+	assert.Equal(t, "blue=14,green=13,red=12", cfg.String())
+
+	_, err = ParseBag("red=12,red=1")
+	assert.Error(t, err)
+	_, err = ParseBag("red")
+	assert.Error(t, err)
+}
+
+func TestValidateGameReport(t *testing.T) {
+	game, err := ParseInputLine("Game 3: 8 green, 6 blue, 20 red; 5 blue, 4 red, 13 green; 5 green, 1 red, 1 yellow")
+	assert.NoError(t, err)
+
+	report := ValidateGameReport(game, PuzzleBag)
+	assert.False(t, report.Possible())
+	assert.Equal(t, []Violation{
+		{Round: 1, Color: "red", Observed: 20, Max: 12},
+		{Round: 3, Color: "yellow", Observed: 1, Max: 0},
+	}, report.Violations)
+	assert.Equal(t, "Game 3: round 1: 20 red, bag holds 12; round 3: 1 yellow, bag holds 0", report.String())
+	assert.Len(t, ImpossibleGames([]CubeGame{game}, PuzzleBag), 1)
+}
+
+func TestParserTolerance(t *testing.T) {
+	expected, err := ParseInputLine("Game 5: 2 red, 3 blue; 4 green, 10 red, 20 blue")
+	assert.NoError(t, err)
+
+	for _, line := range []string{
+		"  game   5 :2 RED,3 Blue ;4 green , 10 red,20 blue;",
+		"GAME 5:\t2 red, 3 blue;4 green, 10 red, 20 blue ; ",
+	} {
+		actual, err := ParseInputLine(line)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+
+	// round-trip
+	assert.Equal(t, "Game 5: 3 blue, 2 red; 20 blue, 4 green, 10 red", expected.String())
+	actual, err := ParseInputLine(expected.String())
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestParserErrors(t *testing.T) {
+	for line, expected := range map[string]string{
+		"Game 1: 2 red blue":    `column 15: expected ',' or ';', got "blue" in "Game 1: 2 red blue"`,
+		"Game 1: 2 red;; 1 red": `column 15: expected "<count> <color>", got ";" in "Game 1: 2 red;; 1 red"`,
+		"Game x: 2 red":         `column 6: expected game id, got "x" in "Game x: 2 red"`,
+		"Game 1 2 red":          `column 8: expected ':' after game id, got "2" in "Game 1 2 red"`,
+		"Game 1: -2 red":        `column 9: unexpected character '-' in "Game 1: -2 red"`,
+	} {
+		_, err := ParseInputLine(line)
+		assert.EqualError(t, err, expected)
+	}
+}
+
+func TestInferBag(t *testing.T) {
+	// rounds drawn without replacement from a bag of 12 red, 13 green and
+	// 14 blue cubes
+	bag := []string{}
+	for color, count := range PuzzleBag {
+		for i := 0; i < count; i++ {
+			bag = append(bag, color)
+		}
+	}
+	sort.Strings(bag)
+	rng := rand.New(rand.NewSource(1))
+	game := CubeGame{Id: 1}
+	for i := 0; i < 2000; i++ {
+		rnd := make(CubeGameRound)
+		rng.Shuffle(len(bag), func(i, j int) { bag[i], bag[j] = bag[j], bag[i] })
+		for _, color := range bag[:1+rng.Intn(8)] {
+			rnd[color] += 1
+		}
+		game.Rounds = append(game.Rounds, rnd)
+	}
+
+	estimate, err := InferBag([]CubeGame{game}, DefaultInferenceOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, 2000, estimate.Rounds)
+	for _, c := range estimate.Colors {
+		assert.True(t, c.Bounded, c.Color)
+		assert.LessOrEqual(t, c.Low, PuzzleBag[c.Color], c.Color)
+		assert.GreaterOrEqual(t, c.High, PuzzleBag[c.Color], c.Color)
+		assert.LessOrEqual(t, c.Low, c.Estimate)
+		assert.GreaterOrEqual(t, c.High, c.Estimate)
+	}
+
+	for _, maxCubes := range []int{-1, 0, 2} {
+		_, err := InferBag([]CubeGame{game}, InferenceOptions{MaxCubes: maxCubes, Confidence: 0.95})
+		assert.Error(t, err, maxCubes)
+	}
+}
@@ -0,0 +1,263 @@
+package day2
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Options of InferBag.
+type InferenceOptions struct {
+	// Upper bound of cubes of a single color considered, the likelihood may
+	// keep growing with the bag size otherwise.
+	MaxCubes int
+	// Confidence level of the intervals, e.g. 0.95.
+	Confidence float64
+}
+
+var DefaultInferenceOptions = InferenceOptions{MaxCubes: 100, Confidence: 0.95}
+
+// Estimated number of cubes of a single color.
+type ColorEstimate struct {
+	Color    string
+	Observed int // most cubes of the color revealed in a single round, a hard lower bound
+	Estimate int // maximum likelihood estimate
+	Low      int // confidence interval, inclusive
+	High     int
+	// Whether the interval ends below InferenceOptions.MaxCubes. If not, the
+	// likelihood doesn't rule out bigger bags and High is only the limit.
+	Bounded bool
+}
+
+// Maximum likelihood estimate of the bag contents.
+type BagEstimate struct {
+	Colors        []ColorEstimate // sorted by color
+	Rounds        int
+	LogLikelihood float64
+}
+
+// Returns the estimate as a bag configuration.
+func (e BagEstimate) Configuration() CubeGameConfiguration {
+	ret := make(CubeGameConfiguration)
+	for _, c := range e.Colors {
+		ret[c.Color] = c.Estimate
+	}
+
+	return ret
+}
+
+// Returns ln C(n, k).
+func logBinomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return math.Inf(-1)
+	}
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+
+	return a - b - c
+}
+
+// Log-likelihood of all rounds as a function of the bag contents.
+//
+// Each round is modeled as a handful of n cubes drawn from the bag without
+// replacement, so the counts of colors revealed follow the multivariate
+// hypergeometric distribution:
+//
+//	P(k | N) = prod_c C(N_c, k_c) / C(sum_c N_c, n)
+//
+// The numerator depends on the count of each color separately and the
+// denominator on the bag size only, so both are tabulated up front and the
+// likelihood of any bag is a sum of table lookups.
+type likelihood struct {
+	colorTerms [][]float64 // colorTerms[c][v] = sum over rounds of ln C(v, k_rc)
+	sizeTerms  []float64   // sizeTerms[N] = sum over rounds of ln C(N, n_r)
+	lower      []int       // smallest possible count of each color
+	maxCubes   int
+}
+
+func newLikelihood(games []CubeGame, colors []string, maxCubes int) *likelihood {
+	ret := &likelihood{
+		colorTerms: make([][]float64, len(colors)),
+		sizeTerms:  make([]float64, len(colors)*maxCubes+1),
+		lower:      make([]int, len(colors)),
+		maxCubes:   maxCubes,
+	}
+	for c := range colors {
+		ret.colorTerms[c] = make([]float64, maxCubes+1)
+	}
+
+	for _, game := range games {
+		for _, rnd := range game.Rounds {
+			handful := 0
+			for c, color := range colors {
+				k := rnd[color]
+				handful += k
+				if k > ret.lower[c] {
+					ret.lower[c] = k
+				}
+				for v := 0; v <= maxCubes; v++ {
+					ret.colorTerms[c][v] += logBinomial(v, k)
+				}
+			}
+			// bags smaller than the handful are impossible, -Inf sticks
+			for size := range ret.sizeTerms {
+				if size < handful {
+					ret.sizeTerms[size] = math.Inf(-1)
+				} else {
+					ret.sizeTerms[size] -= logBinomial(size, handful)
+				}
+			}
+		}
+	}
+
+	return ret
+}
+
+// Maximizes the color terms over all counts summing to each bag size.
+// Returns best[size] and choices[c][size], the count of color c in the
+// best counts of colors 0..c summing to size, for backtracking. Color skip
+// is left out, i.e. its count is zero.
+//
+// The likelihood isn't unimodal along single colors, so the maximum is
+// searched exhaustively. Thanks to the separable terms, colors are added
+// one at a time by a max-plus convolution instead of enumerating all bags.
+func (l *likelihood) convolve(skip int) ([]float64, [][]int) {
+	best := []float64{0}
+	choices := make([][]int, len(l.colorTerms))
+
+	for c, terms := range l.colorTerms {
+		if c == skip {
+			choices[c] = make([]int, len(best))
+			continue
+		}
+
+		next := make([]float64, len(best)+l.maxCubes)
+		for i := range next {
+			next[i] = math.Inf(-1)
+		}
+		choices[c] = make([]int, len(next))
+		for size, value := range best {
+			if math.IsInf(value, -1) {
+				continue
+			}
+			for v := l.lower[c]; v <= l.maxCubes; v++ {
+				if total := value + terms[v]; total > next[size+v] {
+					next[size+v] = total
+					choices[c][size+v] = v
+				}
+			}
+		}
+		best = next
+	}
+
+	return best, choices
+}
+
+// Returns the maximum of the log-likelihood and the counts of colors
+// reaching it. In case fixed is a color, its count is fixed to value.
+func (l *likelihood) maximize(fixed, value int) (float64, []int) {
+	best, choices := l.convolve(fixed)
+
+	offset := 0
+	fixedTerm := 0.0
+	if fixed >= 0 {
+		offset = value
+		fixedTerm = l.colorTerms[fixed][value]
+	}
+
+	ret := math.Inf(-1)
+	retSize := 0
+	for size, colorTerms := range best {
+		if size+offset >= len(l.sizeTerms) {
+			break
+		}
+		if total := colorTerms + fixedTerm + l.sizeTerms[size+offset]; total > ret {
+			ret = total
+			retSize = size
+		}
+	}
+
+	counts := make([]int, len(l.colorTerms))
+	for c := len(counts) - 1; c >= 0; c-- {
+		if c == fixed {
+			counts[c] = value
+			continue
+		}
+		counts[c] = choices[c][retSize]
+		retSize -= counts[c]
+	}
+
+	return ret, counts
+}
+
+// Estimates the bag contents most likely to produce all rounds of the games,
+// assuming all games were played with the same bag and the cubes are put
+// back after each round.
+//
+// Intervals are profile likelihood intervals: all counts of the color for
+// which the likelihood, maximized over the other colors, stays within the
+// chi-squared bound of the maximum.
+func InferBag(games []CubeGame, opts InferenceOptions) (BagEstimate, error) {
+	var ret BagEstimate
+
+	if opts.Confidence <= 0 || opts.Confidence >= 1 {
+		return ret, fmt.Errorf("confidence must be between 0 and 1, got %v", opts.Confidence)
+	}
+	if opts.MaxCubes < 1 {
+		return ret, fmt.Errorf("at most %d cubes of a color considered, need at least 1", opts.MaxCubes)
+	}
+	colors := Colors(games)
+	if len(colors) == 0 {
+		return ret, errors.New("no cubes revealed in any round")
+	}
+	observed := make(CubeGameConfiguration)
+	for _, game := range games {
+		for color, count := range CalcMinimumConfiguration(game) {
+			observed[color] = max(observed[color], count)
+		}
+	}
+	for _, color := range colors {
+		if observed[color] > opts.MaxCubes {
+			return ret, fmt.Errorf("%d %s cubes revealed in a round, more than at most %d considered", observed[color], color, opts.MaxCubes)
+		}
+	}
+
+	l := newLikelihood(games, colors, opts.MaxCubes)
+	for _, game := range games {
+		ret.Rounds += len(game.Rounds)
+	}
+
+	var counts []int
+	ret.LogLikelihood, counts = l.maximize(-1, 0)
+
+	// likelihood ratio bound of a single parameter, chi-squared quantile with
+	// one degree of freedom
+	z := math.Sqrt2 * math.Erfinv(opts.Confidence)
+	bound := ret.LogLikelihood - z*z/2
+
+	for c, color := range colors {
+		estimate := ColorEstimate{
+			Color:    color,
+			Observed: l.lower[c],
+			Estimate: counts[c],
+			Low:      counts[c],
+			High:     counts[c],
+		}
+		for v := l.lower[c]; v <= opts.MaxCubes; v++ {
+			if profile, _ := l.maximize(c, v); profile < bound {
+				continue
+			}
+			if v < estimate.Low {
+				estimate.Low = v
+			}
+			if v > estimate.High {
+				estimate.High = v
+			}
+		}
+		estimate.Bounded = estimate.High < opts.MaxCubes
+		ret.Colors = append(ret.Colors, estimate)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,277 @@
+package day2
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/prki/aoc2023/lib/input"
+)
+
+// Grammar of a game line, keywords and colors are case-insensitive and
+// whitespace may appear between any tokens:
+//
+//	game  = "Game" NUMBER ":" round { ";" round } [ ";" ]
+//	round = draw { "," draw }
+//	draw  = NUMBER COLOR
+
+type TokenKind int
+
+const (
+	TokenEOF TokenKind = iota
+	TokenNumber
+	TokenWord
+	TokenColon
+	TokenComma
+	TokenSemicolon
+)
+
+// Token of a game line.
+type Token struct {
+	Kind   TokenKind
+	Text   string
+	Column int // 1-based byte column of Text in the line
+}
+
+// Describes the token for error messages.
+func (t Token) String() string {
+	if t.Kind == TokenEOF {
+		return "end of line"
+	}
+
+	return strconv.Quote(t.Text)
+}
+
+// Splits a game line into tokens. The last token is always TokenEOF.
+// Errors are of type *input.ParseError.
+func Tokenize(line string) ([]Token, error) {
+	var ret []Token
+
+	field := input.NewLine(line)
+	i := 0
+	for i < len(line) {
+		r, size := utf8.DecodeRuneInString(line[i:])
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+			continue
+		case r >= '0' && r <= '9':
+			for i < len(line) && line[i] >= '0' && line[i] <= '9' {
+				i++
+			}
+			ret = append(ret, Token{Kind: TokenNumber, Text: line[start:i], Column: start + 1})
+			continue
+		case unicode.IsLetter(r):
+			for i < len(line) {
+				r, size := utf8.DecodeRuneInString(line[i:])
+				if !unicode.IsLetter(r) {
+					break
+				}
+				i += size
+			}
+			ret = append(ret, Token{Kind: TokenWord, Text: line[start:i], Column: start + 1})
+			continue
+		case r == ':':
+			ret = append(ret, Token{Kind: TokenColon, Text: ":", Column: start + 1})
+		case r == ',':
+			ret = append(ret, Token{Kind: TokenComma, Text: ",", Column: start + 1})
+		case r == ';':
+			ret = append(ret, Token{Kind: TokenSemicolon, Text: ";", Column: start + 1})
+		default:
+			return ret, field.Slice(start, start+size).Errorf("unexpected character %q", r)
+		}
+		i += size
+	}
+	ret = append(ret, Token{Kind: TokenEOF, Column: len(line) + 1})
+
+	return ret, nil
+}
+
+// Recursive descent parser of a single game line. Errors in draws are
+// collected and parsing continues with the next draw, so that all problems
+// of the line are reported at once.
+type Parser struct {
+	line   string
+	tokens []Token
+	pos    int
+	errs   input.ErrorList
+}
+
+func NewParser(line string) *Parser {
+	return &Parser{line: line}
+}
+
+func (p *Parser) peek() Token {
+	return p.tokens[p.pos]
+}
+
+func (p *Parser) next() Token {
+	ret := p.tokens[p.pos]
+	if ret.Kind != TokenEOF {
+		p.pos++
+	}
+
+	return ret
+}
+
+func (p *Parser) errorf(tok Token, format string, args ...any) {
+	p.errs.Add(&input.ParseError{
+		Column: tok.Column,
+		Input:  p.line,
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+// Skips tokens up to the next draw or round separator.
+func (p *Parser) skipDraw() {
+	for {
+		switch p.peek().Kind {
+		case TokenComma, TokenSemicolon, TokenEOF:
+			return
+		}
+		p.next()
+	}
+}
+
+// Skips tokens up to the next round separator.
+func (p *Parser) skipRound() {
+	for p.peek().Kind != TokenSemicolon && p.peek().Kind != TokenEOF {
+		p.next()
+	}
+}
+
+// Parses the line. All problems found are returned as an input.ErrorList.
+func (p *Parser) ParseGame() (CubeGame, error) {
+	var ret CubeGame
+
+	tokens, err := Tokenize(p.line)
+	if err != nil {
+		return ret, input.ErrorList{err.(*input.ParseError)}
+	}
+	p.tokens = tokens
+
+	if !p.parseHeader(&ret) {
+		return ret, p.errs.Err()
+	}
+
+	ret.Rounds = append(ret.Rounds, p.parseRound())
+	for {
+		tok := p.next()
+		if tok.Kind == TokenEOF {
+			break
+		}
+		if tok.Kind != TokenSemicolon {
+			p.errorf(tok, "expected ',' or ';', got %s", tok)
+			p.skipRound()
+			continue
+		}
+		// trailing semicolon
+		if p.peek().Kind == TokenEOF {
+			break
+		}
+		ret.Rounds = append(ret.Rounds, p.parseRound())
+	}
+
+	return ret, p.errs.Err()
+}
+
+func (p *Parser) parseHeader(game *CubeGame) bool {
+	tok := p.next()
+	if tok.Kind != TokenWord || !strings.EqualFold(tok.Text, "game") {
+		p.errorf(tok, "expected \"Game <id>:\", got %s", tok)
+		return false
+	}
+
+	tok = p.next()
+	if tok.Kind != TokenNumber {
+		p.errorf(tok, "expected game id, got %s", tok)
+		return false
+	}
+	id, err := strconv.Atoi(tok.Text)
+	if err != nil {
+		p.errorf(tok, "invalid game id %q", tok.Text)
+		return false
+	}
+	game.Id = id
+
+	tok = p.next()
+	if tok.Kind != TokenColon {
+		p.errorf(tok, "expected ':' after game id, got %s", tok)
+		return false
+	}
+
+	return true
+}
+
+func (p *Parser) parseRound() CubeGameRound {
+	ret := make(CubeGameRound)
+
+	p.parseDraw(ret)
+	for p.peek().Kind == TokenComma {
+		p.next()
+		p.parseDraw(ret)
+	}
+
+	return ret
+}
+
+func (p *Parser) parseDraw(rnd CubeGameRound) {
+	tok := p.peek()
+	if tok.Kind != TokenNumber {
+		if tok.Kind == TokenWord {
+			p.errorf(tok, "invalid cube count %q", tok.Text)
+		} else {
+			p.errorf(tok, "expected \"<count> <color>\", got %s", tok)
+		}
+		p.skipDraw()
+		return
+	}
+	p.next()
+	count, err := strconv.Atoi(tok.Text)
+	if err != nil {
+		p.errorf(tok, "invalid cube count %q", tok.Text)
+		p.skipDraw()
+		return
+	}
+
+	tok = p.peek()
+	if tok.Kind != TokenWord {
+		p.errorf(tok, "expected cube color, got %s", tok)
+		p.skipDraw()
+		return
+	}
+	p.next()
+	rnd[strings.ToLower(tok.Text)] += count
+}
+
+// Returns the draws of the round as "<count> <color>" sorted by color,
+// e.g. "3 blue, 2 red".
+func (rnd CubeGameRound) String() string {
+	colors := make([]string, 0, len(rnd))
+	for color := range rnd {
+		colors = append(colors, color)
+	}
+	sort.Strings(colors)
+
+	draws := make([]string, len(colors))
+	for i, color := range colors {
+		draws[i] = strconv.Itoa(rnd[color]) + " " + color
+	}
+
+	return strings.Join(draws, ", ")
+}
+
+// Returns the game in the canonical input format. Parsing the returned line
+// gives an equal game.
+func (g CubeGame) String() string {
+	rounds := make([]string, len(g.Rounds))
+	for i, rnd := range g.Rounds {
+		rounds[i] = rnd.String()
+	}
+
+	return fmt.Sprintf("Game %d: %s", g.Id, strings.Join(rounds, "; "))
+}
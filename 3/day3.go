@@ -0,0 +1,202 @@
+package day3
+
+/*Solution to 3 is built by creating a bounding box around all numbers to check
+symbols in. If there is any symbol in this bounding box other than '.', we know
+it's a "part number".
+
+For part 2, a hashmap symbol -> []int is created, listing the numbers adjacent
+to the symbol at a coord. Gears are then picked from it by a GearRule, for the
+puzzle an asterisk with exactly two numbers, valued by their product.
+
+Another approach is creating a graph where nodes are numbers/symbols and an
+edge exists between them if they are adjacent, see Graph. It isn't needed to
+solve the puzzle but allows inspecting the schematic.
+*/
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/prki/aoc2023/lib/grid"
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/prki/aoc2023/lib/logging"
+)
+
+var logger = logging.Day(3)
+
+// Symbol on a schematic and its coordinates.
+type Symbol struct {
+	Symbol rune
+	Coords grid.Point
+}
+
+// Parses the diagram into a grid. CRLF line endings and trailing blank lines
+// are ignored. Rows shorter than the widest one are padded with '.', rows
+// differing in width from the first one are returned as warnings.
+func LoadSchematic(diagram []string) (*grid.Grid[rune], input.ErrorList) {
+	var warnings input.ErrorList
+
+	rows := make([]string, 0, len(diagram))
+	for _, line := range diagram {
+		rows = append(rows, strings.TrimSuffix(line, "\r"))
+	}
+	for len(rows) > 0 && len(strings.TrimSpace(rows[len(rows)-1])) == 0 {
+		rows = rows[:len(rows)-1]
+	}
+
+	width := 0
+	for y, row := range rows {
+		rowWidth := utf8.RuneCountInString(row)
+		if y > 0 && rowWidth != utf8.RuneCountInString(rows[0]) {
+			warnings.Add(&input.ParseError{
+				Line:   y + 1,
+				Input:  row,
+				Reason: fmt.Sprintf("row is %d cells wide, first row is %d", rowWidth, utf8.RuneCountInString(rows[0])),
+			})
+		}
+		if rowWidth > width {
+			width = rowWidth
+		}
+	}
+	ret := grid.Filled(width, len(rows), '.')
+	for y, row := range rows {
+		x := 0
+		for _, r := range row {
+			ret.Set(grid.Point{X: x, Y: y}, r)
+			x++
+		}
+	}
+
+	return ret, warnings
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// Symbols are all characters other than digits and '.'.
+func isSymbol(r rune) bool {
+	return r != '.' && !isDigit(r)
+}
+
+// Create a bounding box, check if bounding box contains non-digit/non-dot
+// E.g.
+//
+//	1 2 3 4 5
+//
+// 1. . . . .
+// 2. | - - |
+// 3. | 6 9 |
+// 4. | - - |
+// 5. . . . .
+// Number takes [3][3] -> [3][4]. Number is only in a row (3-4)
+// Bounding points [2][2] [2][3] [2][4] [2][5]
+// Bounding points [3][2]               [3][5]
+// Bounding points [4][2] [4][3] [4][4] [4][5]
+// The box is clipped to the schematic. numEnd is the point just after the
+// last digit of the number. Returns the first symbol found, row by row; see
+// AdjacentSymbols for all of them.
+func IsSymbolInBoundingBox(schematic *grid.Grid[rune], numStart, numEnd grid.Point) (bool, string, grid.Point) {
+	symbols := AdjacentSymbols(schematic, numStart, numEnd)
+	if len(symbols) == 0 {
+		return false, "", grid.Point{}
+	}
+
+	return true, string(symbols[0].Symbol), symbols[0].Coords
+}
+
+// Returns all symbols in the bounding box of the number from numStart to
+// numEnd (exclusive), row by row.
+func AdjacentSymbols(schematic *grid.Grid[rune], numStart, numEnd grid.Point) []Symbol {
+	var ret []Symbol
+
+	boxMin := numStart.Add(grid.NorthWest)
+	boxMax := numEnd.Add(grid.South)
+	schematic.Region(boxMin, boxMax)(func(p grid.Point, r rune) bool {
+		if isSymbol(r) {
+			ret = append(ret, Symbol{Symbol: r, Coords: p})
+		}
+		return true
+	})
+
+	return ret
+}
+
+// Prints the schematic as is to w, e.g. os.Stderr. See Annotate for a
+// highlighted rendering.
+func PrintSchematic(w io.Writer, schematic *grid.Grid[rune]) {
+	fmt.Fprint(w, schematic)
+}
+
+// Returns the number starting on numStart and the point just after its
+// last digit.
+func FindNumberInSchematic(schematic *grid.Grid[rune], numStart grid.Point) (int, grid.Point) {
+	var numRunes []rune
+
+	retCoord := numStart
+	for r, ok := schematic.At(retCoord); ok && isDigit(r); r, ok = schematic.At(retCoord) {
+		numRunes = append(numRunes, r)
+		retCoord = retCoord.Add(grid.East)
+	}
+
+	retNum, _ := strconv.Atoi(string(numRunes))
+
+	return retNum, retCoord
+}
+
+// Returns the sum of part numbers and the sum of gear ratios.
+func Solve(diagram []string) (int, int) {
+	partSum, index := IndexDiagram(diagram)
+
+	return partSum, index.Sum(PuzzleGearRule)
+}
+
+// Loads the diagram, logging warnings, and returns the sum of part numbers
+// and the index of its symbols.
+func IndexDiagram(diagram []string) (int, SymbolIndex) {
+	return IndexSchematic(LoadDiagram(diagram))
+}
+
+// Same as LoadSchematic, logging the warnings.
+func LoadDiagram(diagram []string) *grid.Grid[rune] {
+	schematic, warnings := LoadSchematic(diagram)
+	for _, warning := range warnings {
+		logger.Warn("ragged schematic row padded with '.'", "line", warning.Line, "reason", warning.Reason)
+	}
+	if logger.Enabled(context.Background(), logging.LevelTrace) {
+		for y := 0; y < schematic.Height(); y++ {
+			var row strings.Builder
+			schematic.Row(y)(func(_ grid.Point, r rune) bool {
+				row.WriteRune(r)
+				return true
+			})
+			logging.Trace(logger, "schematic row", "y", y, "row", row.String())
+		}
+	}
+
+	return schematic
+}
+
+func Part1(r io.Reader) (string, error) {
+	diagram, err := input.ReadLines(r)
+	if err != nil {
+		return "", err
+	}
+	solution1, _ := Solve(diagram)
+
+	return strconv.Itoa(solution1), nil
+}
+
+func Part2(r io.Reader) (string, error) {
+	diagram, err := input.ReadLines(r)
+	if err != nil {
+		return "", err
+	}
+	_, solution2 := Solve(diagram)
+
+	return strconv.Itoa(solution2), nil
+}
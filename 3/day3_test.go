@@ -0,0 +1,223 @@
+package day3
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/prki/aoc2023/lib/grid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSchematicRagged(t *testing.T) {
+	diagram := []string{
+		"467..114\r",
+		"...*\r",
+		"..35..633.\r",
+		"",
+		"  ",
+	}
+	schematic, warnings := LoadSchematic(diagram)
+	assert.Equal(t, "467..114..\n...*......\n..35..633.\n", schematic.String())
+	assert.Len(t, warnings, 2)
+	assert.Equal(t, 2, warnings[0].Line)
+	assert.Equal(t, "row is 4 cells wide, first row is 8", warnings[0].Reason)
+	assert.Equal(t, 3, warnings[1].Line)
+
+	num, end := FindNumberInSchematic(schematic, grid.Point{X: 6, Y: 2})
+	assert.Equal(t, 633, num)
+	assert.Equal(t, grid.Point{X: 9, Y: 2}, end)
+
+	sum, ratios := Solve(diagram)
+	assert.Equal(t, 467+35, sum)
+	assert.Equal(t, 467*35, ratios)
+}
+
+// 12 touches '#' before '*' in reading order, it must still count towards
+// the gear.
+func TestAdjacentSymbols(t *testing.T) {
+	diagram := []string{
+		"#.....",
+		".12*34",
+	}
+	schematic, _ := LoadSchematic(diagram)
+	symbols := AdjacentSymbols(schematic, grid.Point{X: 1, Y: 1}, grid.Point{X: 3, Y: 1})
+	assert.Equal(t, []Symbol{
+		{Symbol: '#', Coords: grid.Point{X: 0, Y: 0}},
+		{Symbol: '*', Coords: grid.Point{X: 3, Y: 1}},
+	}, symbols)
+
+	found, symbol, coords := IsSymbolInBoundingBox(schematic, grid.Point{X: 1, Y: 1}, grid.Point{X: 3, Y: 1})
+	assert.True(t, found)
+	assert.Equal(t, "#", symbol)
+	assert.Equal(t, grid.Point{X: 0, Y: 0}, coords)
+
+	sum, ratios := Solve(diagram)
+	assert.Equal(t, 12+34, sum)
+	assert.Equal(t, 12*34, ratios)
+}
+
+// Example schematic of the puzzle.
+var example = []string{
+	"467..114..",
+	"...*......",
+	"..35..633.",
+	"......#...",
+	"617*......",
+	".....+.58.",
+	"..592.....",
+	"......755.",
+	"...$.*....",
+	".664.598..",
+}
+
+func TestGearRules(t *testing.T) {
+	_, index := IndexDiagram(example)
+
+	assert.Equal(t, 467835, index.Sum(PuzzleGearRule))
+
+	rule, err := ParseGearRule("symbols=*#,min=1,aggregate=sum")
+	assert.NoError(t, err)
+	assert.Equal(t, GearRule{Symbols: "*#", Count: 1, AtLeast: true, Aggregate: AggregateSum}, rule)
+	assert.Equal(t, "symbols=*#,min=1,aggregate=sum", rule.String())
+	gears := index.Gears(rule)
+	assert.Len(t, gears, 4)
+	assert.Equal(t, Gear{Symbol: Symbol{Symbol: '*', Coords: grid.Point{X: 3, Y: 1}}, Numbers: []int{467, 35}, Value: 502}, gears[0])
+	assert.Equal(t, 502+633+617+755+598, index.Sum(rule))
+
+	rule, err = ParseGearRule("symbols=,count=1,aggregate=max")
+	assert.NoError(t, err)
+	assert.Equal(t, 633+617+592+664, index.Sum(rule))
+
+	for _, spec := range []string{"count=-1", "count=0", "min=0", "aggregate=min", "size=2", "symbols"} {
+		_, err := ParseGearRule(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+// Solver and renderer agree that a symbol without numbers is never a gear.
+func TestGearRuleWithoutNumbers(t *testing.T) {
+	diagram := []string{"*..", "..1"}
+	rule := GearRule{Count: 0, AtLeast: true, Aggregate: AggregateProduct}
+	_, index := IndexDiagram(diagram)
+	assert.Empty(t, index.Gears(rule))
+
+	schematic, _ := LoadSchematic(diagram)
+	annotated := Annotate(schematic, rule)
+	assert.Equal(t, CellSymbol, annotated.Kinds.Get(grid.Point{X: 0, Y: 0}))
+}
+
+func TestGraph(t *testing.T) {
+	schematic, _ := LoadSchematic(example)
+	graph := BuildGraph(schematic)
+	assert.Len(t, graph.Nodes, 16)
+	assert.Len(t, graph.Edges, 8)
+
+	gear, ok := graph.Symbol(grid.Point{X: 3, Y: 1})
+	assert.True(t, ok)
+	assert.Equal(t, "*", gear.Text)
+	touching := graph.NumbersTouching(grid.Point{X: 3, Y: 1})
+	assert.Len(t, touching, 2)
+	assert.Equal(t, 467, touching[0].Value)
+	assert.Equal(t, grid.Point{X: 3, Y: 0}, touching[0].End)
+	assert.Equal(t, 35, touching[1].Value)
+	assert.Empty(t, graph.NumbersTouching(grid.Point{X: 0, Y: 0}))
+	assert.True(t, graph.IsPartNumber(touching[0]))
+	assert.False(t, graph.IsPartNumber(graph.Nodes[1])) // 114
+
+	var values [][]int
+	for _, component := range graph.Components() {
+		var nums []int
+		for _, n := range component {
+			nums = append(nums, n.Value)
+		}
+		values = append(values, nums)
+	}
+	assert.Equal(t, [][]int{{467, 35}, {114}, {633}, {617}, {58}, {592}, {755, 598}, {664}}, values)
+
+	var dot bytes.Buffer
+	assert.NoError(t, graph.WriteDOT(&dot))
+	assert.True(t, strings.HasPrefix(dot.String(), "graph schematic {\n  n0 [shape=box, label=\"467\\n0,0\"];\n"))
+	assert.Contains(t, dot.String(), "  n0 -- n2;\n")
+
+	var out bytes.Buffer
+	assert.NoError(t, graph.WriteJSON(&out))
+	var decoded Graph
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, graph.Nodes, decoded.Nodes)
+	assert.Equal(t, graph.Edges, decoded.Edges)
+
+	schematic, _ = LoadSchematic([]string{"0*"})
+	out.Reset()
+	assert.NoError(t, BuildGraph(schematic).WriteJSON(&out))
+	var nodes struct{ Nodes []map[string]any }
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &nodes))
+	assert.Equal(t, 0.0, nodes.Nodes[0]["value"])
+	assert.NotContains(t, nodes.Nodes[1], "value")
+}
+
+func TestAnnotate(t *testing.T) {
+	schematic, _ := LoadSchematic(example)
+	annotated := Annotate(schematic, PuzzleGearRule)
+	assert.Equal(t, CellPartNumber, annotated.Kinds.Get(grid.Point{X: 1, Y: 0}))
+	assert.Equal(t, CellNumber, annotated.Kinds.Get(grid.Point{X: 5, Y: 0}))
+	assert.Equal(t, CellEmpty, annotated.Kinds.Get(grid.Point{X: 0, Y: 1}))
+	assert.Equal(t, CellGear, annotated.Kinds.Get(grid.Point{X: 3, Y: 1}))
+	assert.Equal(t, CellSymbol, annotated.Kinds.Get(grid.Point{X: 3, Y: 4}))
+	assert.Equal(t, CellSymbol, annotated.Kinds.Get(grid.Point{X: 6, Y: 3}))
+	assert.Equal(t, "gear * at 3,1, adjacent to 467 at 0,0, 35 at 2,2, product 16345", annotated.Tooltips.Get(grid.Point{X: 3, Y: 1}))
+	assert.Equal(t, "part number 35 at 2,2, adjacent to * at 3,1", annotated.Tooltips.Get(grid.Point{X: 3, Y: 2}))
+
+	var ansi bytes.Buffer
+	assert.NoError(t, annotated.WriteANSI(&ansi, false))
+	lines := strings.Split(ansi.String(), "\n")
+	assert.Equal(t, "\x1b[32m467\x1b[0m..\x1b[2m114\x1b[0m..", lines[0])
+	assert.Equal(t, "...\x1b[1;35m*\x1b[0m......", lines[1])
+
+	var page bytes.Buffer
+	assert.NoError(t, annotated.WriteHTML(&page, true))
+	assert.Contains(t, page.String(), `<span class="gear" data-x="3" data-y="1" title="gear * at 3,1, adjacent to 467 at 0,0, 35 at 2,2, product 16345">*</span>`)
+	assert.Contains(t, page.String(), `(gears: symbols=*,count=2,aggregate=product)</p>`)
+}
+
+func TestSolveStream(t *testing.T) {
+	var parts []int
+	var gears []Gear
+	opts := StreamOptions{
+		Rules:  []GearRule{PuzzleGearRule, {Symbols: "", Count: 1, AtLeast: true, Aggregate: AggregateSum}},
+		OnPart: func(part PartNumber) { parts = append(parts, part.Value) },
+		OnGear: func(rule int, gear Gear) {
+			if rule == 0 {
+				gears = append(gears, gear)
+			}
+		},
+	}
+	totals, err := SolveStream(strings.NewReader(strings.Join(example, "\r\n")+"\r\n"), opts)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, totals.Rows)
+	assert.Equal(t, 4361, totals.PartSum)
+	assert.Equal(t, []int{467835, 4361}, totals.GearSums)
+	assert.Equal(t, []int{467, 35, 633, 617, 592, 755, 664, 598}, parts)
+	assert.Equal(t, []Gear{
+		{Symbol: Symbol{Symbol: '*', Coords: grid.Point{X: 3, Y: 1}}, Numbers: []int{467, 35}, Value: 16345},
+		{Symbol: Symbol{Symbol: '*', Coords: grid.Point{X: 5, Y: 8}}, Numbers: []int{755, 598}, Value: 451490},
+	}, gears)
+
+	for _, diagram := range [][]string{{"#.....", ".12*34"}, {"467..114", "...*", "..35..633.", "", "  "}, {"1*2"}, {}, {"12.", "   ", ""}, {"12.", "   ", "..3"}, {"12.", " ", "", "   ", "", "  ", "..3"}} {
+		sum, ratios := Solve(diagram)
+		totals, err := SolveStream(strings.NewReader(strings.Join(diagram, "\n")), StreamOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, sum, totals.PartSum, diagram)
+		assert.Equal(t, []int{ratios}, totals.GearSums, diagram)
+	}
+
+	totals, err = SolveStream(strings.NewReader("1\n \n\n\n"), StreamOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, totals.Rows)
+	assert.Equal(t, 0, totals.PartSum)
+	totals, err = SolveStream(strings.NewReader("1\n \n\n \n \n2"), StreamOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, totals.Rows)
+	assert.Equal(t, 3, totals.PartSum)
+}
@@ -0,0 +1,192 @@
+package day3
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prki/aoc2023/lib/grid"
+)
+
+// Part numbers adjacent to each symbol of a schematic. A number is listed
+// once for every symbol it touches.
+type SymbolIndex map[Symbol][]int
+
+// Adds partNumber to the numbers adjacent to symbol.
+func (idx SymbolIndex) Register(symbol Symbol, partNumber int) {
+	idx[symbol] = append(idx[symbol], partNumber)
+}
+
+// Returns the sum of part numbers and the index of all symbols of the
+// schematic.
+func IndexSchematic(schematic *grid.Grid[rune]) (int, SymbolIndex) {
+	partSum := 0
+	ret := make(SymbolIndex)
+
+	for y := 0; y < schematic.Height(); y++ {
+		for x := 0; x < schematic.Width(); x++ {
+			numStartCoord := grid.Point{X: x, Y: y}
+			if !isDigit(schematic.Get(numStartCoord)) {
+				continue
+			}
+			num, numEndCoord := FindNumberInSchematic(schematic, numStartCoord)
+			symbols := AdjacentSymbols(schematic, numStartCoord, numEndCoord)
+			if len(symbols) > 0 {
+				logger.Debug("part number found", "number", num, "start", numStartCoord, "end", numEndCoord, "symbols", symbols)
+				partSum += num
+			}
+			for _, symbol := range symbols {
+				ret.Register(symbol, num)
+			}
+			x = numEndCoord.X
+		}
+	}
+
+	return partSum, ret
+}
+
+// Aggregation of part numbers adjacent to a gear into its value.
+type Aggregation string
+
+const (
+	AggregateProduct Aggregation = "product"
+	AggregateSum     Aggregation = "sum"
+	AggregateMax     Aggregation = "max"
+)
+
+// Returns the aggregated value of nums.
+func (a Aggregation) Apply(nums []int) int {
+	ret := 0
+
+	switch a {
+	case AggregateProduct:
+		ret = 1
+		for _, num := range nums {
+			ret *= num
+		}
+	case AggregateSum:
+		for _, num := range nums {
+			ret += num
+		}
+	case AggregateMax:
+		for _, num := range nums {
+			ret = max(ret, num)
+		}
+	}
+
+	return ret
+}
+
+// Rule deciding which symbols are gears and what their value is.
+type GearRule struct {
+	Symbols   string // symbols counted as gears, empty for all of them
+	Count     int    // number of adjacent part numbers
+	AtLeast   bool   // Count is the minimum rather than the exact number
+	Aggregate Aggregation
+}
+
+// Gear rule of part 2 of the puzzle.
+var PuzzleGearRule = GearRule{Symbols: "*", Count: 2, Aggregate: AggregateProduct}
+
+// Returns whether symbol with the adjacent part numbers nums is a gear.
+// Symbols without adjacent numbers are never gears, as they are not in
+// a SymbolIndex.
+func (rule GearRule) Matches(symbol Symbol, nums []int) bool {
+	if len(nums) == 0 {
+		return false
+	}
+	if len(rule.Symbols) > 0 && !strings.ContainsRune(rule.Symbols, symbol.Symbol) {
+		return false
+	}
+	if rule.AtLeast {
+		return len(nums) >= rule.Count
+	}
+
+	return len(nums) == rule.Count
+}
+
+// Returns the rule in the format accepted by ParseGearRule, e.g.
+// "symbols=*,count=2,aggregate=product".
+func (rule GearRule) String() string {
+	count := "count=" + strconv.Itoa(rule.Count)
+	if rule.AtLeast {
+		count = "min=" + strconv.Itoa(rule.Count)
+	}
+
+	return fmt.Sprintf("symbols=%s,%s,aggregate=%s", rule.Symbols, count, rule.Aggregate)
+}
+
+// Parses a gear rule of comma separated "key=value" items overriding
+// PuzzleGearRule:
+//
+//   - symbols: characters counted as gears, empty for all symbols
+//   - count: exact number of adjacent part numbers, at least 1
+//   - min: minimum number of adjacent part numbers, at least 1
+//   - aggregate: product, sum or max
+//
+// E.g. "symbols=*#,min=1,aggregate=sum". A ',' can't be given as a symbol.
+func ParseGearRule(spec string) (GearRule, error) {
+	ret := PuzzleGearRule
+
+	for _, item := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return GearRule{}, fmt.Errorf("invalid gear rule item %q, expected \"<key>=<value>\"", item)
+		}
+		switch strings.TrimSpace(key) {
+		case "symbols":
+			ret.Symbols = strings.TrimSpace(value)
+		case "count", "min":
+			count, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || count < 1 {
+				return GearRule{}, fmt.Errorf("invalid part number count in gear rule item %q", item)
+			}
+			ret.Count, ret.AtLeast = count, strings.TrimSpace(key) == "min"
+		case "aggregate":
+			ret.Aggregate = Aggregation(strings.TrimSpace(value))
+			if ret.Aggregate != AggregateProduct && ret.Aggregate != AggregateSum && ret.Aggregate != AggregateMax {
+				return GearRule{}, fmt.Errorf("unknown aggregation %q, expected product, sum or max", value)
+			}
+		default:
+			return GearRule{}, fmt.Errorf("unknown gear rule key %q", key)
+		}
+	}
+
+	return ret, nil
+}
+
+// Gear found by a rule.
+type Gear struct {
+	Symbol
+	Numbers []int // adjacent part numbers
+	Value   int
+}
+
+// Returns gears of the index according to rule, row by row.
+func (idx SymbolIndex) Gears(rule GearRule) []Gear {
+	var ret []Gear
+
+	for symbol, nums := range idx {
+		if rule.Matches(symbol, nums) {
+			ret = append(ret, Gear{Symbol: symbol, Numbers: nums, Value: rule.Aggregate.Apply(nums)})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		a, b := ret[i].Coords, ret[j].Coords
+		return a.Y < b.Y || (a.Y == b.Y && a.X < b.X)
+	})
+
+	return ret
+}
+
+// Returns the sum of values of gears according to rule.
+func (idx SymbolIndex) Sum(rule GearRule) int {
+	ret := 0
+	for _, gear := range idx.Gears(rule) {
+		logger.Debug("gear found", "symbol", string(gear.Symbol.Symbol), "coords", gear.Coords, "numbers", gear.Numbers, "value", gear.Value)
+		ret += gear.Value
+	}
+
+	return ret
+}
@@ -0,0 +1,199 @@
+package day3
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/prki/aoc2023/lib/grid"
+)
+
+type NodeKind string
+
+const (
+	NodeNumber NodeKind = "number"
+	NodeSymbol NodeKind = "symbol"
+)
+
+// Number or symbol of a schematic. Nodes span a single row from Start to End
+// (exclusive), symbols span a single cell.
+type Node struct {
+	Id    int        `json:"id"` // index in Graph.Nodes
+	Kind  NodeKind   `json:"kind"`
+	Text  string     `json:"text"`  // digits of a number or the symbol
+	Value int        `json:"value"` // value of a number, omitted for symbols
+	Start grid.Point `json:"start"`
+	End   grid.Point `json:"end"`
+}
+
+// Encodes the node with the value of numbers only, so that a part number 0
+// keeps its value.
+func (n Node) MarshalJSON() ([]byte, error) {
+	type node Node // without the MarshalJSON method
+	ret := struct {
+		node
+		Value *int `json:"value,omitempty"`
+	}{node: node(n)}
+	if n.Kind == NodeNumber {
+		ret.Value = &n.Value
+	}
+
+	return json.Marshal(ret)
+}
+
+// Adjacency of a number and a symbol, given by node ids.
+type Edge struct {
+	Number int `json:"number"`
+	Symbol int `json:"symbol"`
+}
+
+// Bipartite graph of numbers and symbols of a schematic, with an edge between
+// each number and every symbol in its bounding box.
+type Graph struct {
+	Nodes []*Node `json:"nodes"` // numbers and symbols row by row
+	Edges []Edge  `json:"edges"`
+
+	symbols   map[grid.Point]*Node
+	neighbors [][]*Node // by node id
+}
+
+// Builds the graph of all numbers and symbols of the schematic.
+func BuildGraph(schematic *grid.Grid[rune]) *Graph {
+	ret := &Graph{
+		Nodes:   []*Node{},
+		Edges:   []Edge{},
+		symbols: make(map[grid.Point]*Node),
+	}
+
+	var numbers []*Node
+	for y := 0; y < schematic.Height(); y++ {
+		for x := 0; x < schematic.Width(); x++ {
+			p := grid.Point{X: x, Y: y}
+			r := schematic.Get(p)
+			if isDigit(r) {
+				num, end := FindNumberInSchematic(schematic, p)
+				node := ret.addNode(NodeNumber, strconv.Itoa(num), p, end)
+				node.Value = num
+				numbers = append(numbers, node)
+				x = end.X - 1
+			} else if isSymbol(r) {
+				ret.symbols[p] = ret.addNode(NodeSymbol, string(r), p, p.Add(grid.East))
+			}
+		}
+	}
+
+	ret.neighbors = make([][]*Node, len(ret.Nodes))
+	for _, number := range numbers {
+		for _, symbol := range AdjacentSymbols(schematic, number.Start, number.End) {
+			symbolNode := ret.symbols[symbol.Coords]
+			ret.Edges = append(ret.Edges, Edge{Number: number.Id, Symbol: symbolNode.Id})
+			ret.neighbors[number.Id] = append(ret.neighbors[number.Id], symbolNode)
+			ret.neighbors[symbolNode.Id] = append(ret.neighbors[symbolNode.Id], number)
+		}
+	}
+
+	return ret
+}
+
+func (g *Graph) addNode(kind NodeKind, text string, start, end grid.Point) *Node {
+	ret := &Node{Id: len(g.Nodes), Kind: kind, Text: text, Start: start, End: end}
+	g.Nodes = append(g.Nodes, ret)
+
+	return ret
+}
+
+// Returns the symbol node on p, if there is one.
+func (g *Graph) Symbol(p grid.Point) (*Node, bool) {
+	ret, ok := g.symbols[p]
+
+	return ret, ok
+}
+
+// Returns symbols adjacent to a number or numbers adjacent to a symbol.
+func (g *Graph) Neighbors(n *Node) []*Node {
+	return g.neighbors[n.Id]
+}
+
+// Returns all numbers touching the symbol on p, empty if there is no symbol.
+func (g *Graph) NumbersTouching(p grid.Point) []*Node {
+	symbol, ok := g.Symbol(p)
+	if !ok {
+		return nil
+	}
+
+	return g.Neighbors(symbol)
+}
+
+// Returns whether the node is a number adjacent to a symbol.
+func (g *Graph) IsPartNumber(n *Node) bool {
+	return n.Kind == NodeNumber && len(g.neighbors[n.Id]) > 0
+}
+
+// Returns groups of numbers linked to each other through shared symbols,
+// ordered by their first number. Numbers without symbols form groups of
+// their own.
+func (g *Graph) Components() [][]*Node {
+	var ret [][]*Node
+
+	visited := make([]bool, len(g.Nodes))
+	for _, start := range g.Nodes {
+		if start.Kind != NodeNumber || visited[start.Id] {
+			continue
+		}
+		var component []*Node
+		visited[start.Id] = true
+		queue := []*Node{start}
+		for len(queue) > 0 {
+			curr := queue[0]
+			queue = queue[1:]
+			if curr.Kind == NodeNumber {
+				component = append(component, curr)
+			}
+			for _, next := range g.neighbors[curr.Id] {
+				if !visited[next.Id] {
+					visited[next.Id] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		ret = append(ret, component)
+	}
+
+	return ret
+}
+
+// Writes the graph in Graphviz DOT format, numbers as boxes labeled with
+// their value and start, symbols as circles.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "graph schematic {"); err != nil {
+		return err
+	}
+	for _, n := range g.Nodes {
+		var err error
+		if n.Kind == NodeNumber {
+			_, err = fmt.Fprintf(w, "  n%d [shape=box, label=%q];\n", n.Id, fmt.Sprintf("%s\n%d,%d", n.Text, n.Start.X, n.Start.Y))
+		} else {
+			_, err = fmt.Fprintf(w, "  n%d [shape=circle, label=%q];\n", n.Id, n.Text)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, e := range g.Edges {
+		if _, err := fmt.Fprintf(w, "  n%d -- n%d;\n", e.Number, e.Symbol); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+
+	return err
+}
+
+// Writes the nodes and edges of the graph as indented JSON.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(g)
+}
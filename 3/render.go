@@ -0,0 +1,167 @@
+package day3
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/prki/aoc2023/lib/grid"
+)
+
+// Role of a schematic cell in the solution.
+type CellKind int
+
+const (
+	CellEmpty      CellKind = iota
+	CellNumber              // digit of a number not adjacent to any symbol
+	CellPartNumber          // digit of a part number
+	CellSymbol              // symbol which isn't a gear
+	CellGear                // symbol which is a gear according to the rule
+)
+
+var cellKindNames = [...]string{"empty", "number", "part", "symbol", "gear"}
+
+var cellKindDescriptions = [...]string{"empty", "number", "part number", "symbol", "gear"}
+
+// Returns the name of the kind, also used as its CSS class, e.g. "part".
+func (k CellKind) String() string {
+	return cellKindNames[k]
+}
+
+const (
+	ansiReset      = "\x1b[0m"
+	ansiNumber     = "\x1b[2m"
+	ansiPartNumber = "\x1b[32m"
+	ansiSymbol     = "\x1b[33m"
+	ansiGear       = "\x1b[1;35m"
+)
+
+var ansiColors = [...]string{ansiReset, ansiNumber, ansiPartNumber, ansiSymbol, ansiGear}
+
+const htmlStyle = `pre { line-height: 1.2; }
+.number { color: #888; }
+.part { color: #080; font-weight: bold; }
+.symbol { color: #b80; }
+.gear { color: #fff; background: #a0a; font-weight: bold; }
+`
+
+// Schematic with every cell classified and described for rendering.
+type Annotated struct {
+	Schematic *grid.Grid[rune]
+	Rule      GearRule
+	Kinds     *grid.Grid[CellKind]
+	Tooltips  *grid.Grid[string] // empty on empty cells
+}
+
+// Classifies cells of the schematic, gears are symbols matched by rule.
+func Annotate(schematic *grid.Grid[rune], rule GearRule) *Annotated {
+	ret := &Annotated{
+		Schematic: schematic,
+		Rule:      rule,
+		Kinds:     grid.New[CellKind](schematic.Width(), schematic.Height()),
+		Tooltips:  grid.New[string](schematic.Width(), schematic.Height()),
+	}
+
+	graph := BuildGraph(schematic)
+	for _, n := range graph.Nodes {
+		neighbors := graph.Neighbors(n)
+		texts := make([]string, len(neighbors))
+		values := make([]int, len(neighbors))
+		for i, neighbor := range neighbors {
+			texts[i] = fmt.Sprintf("%s at %d,%d", neighbor.Text, neighbor.Start.X, neighbor.Start.Y)
+			values[i] = neighbor.Value
+		}
+
+		kind := CellNumber
+		if n.Kind == NodeSymbol {
+			kind = CellSymbol
+			symbol := Symbol{Symbol: []rune(n.Text)[0], Coords: n.Start}
+			if rule.Matches(symbol, values) {
+				kind = CellGear
+			}
+		} else if len(neighbors) > 0 {
+			kind = CellPartNumber
+		}
+
+		tooltip := fmt.Sprintf("%s %s at %d,%d", cellKindDescriptions[kind], n.Text, n.Start.X, n.Start.Y)
+		if len(texts) > 0 {
+			tooltip += ", adjacent to " + strings.Join(texts, ", ")
+		}
+		if kind == CellGear {
+			tooltip += fmt.Sprintf(", %s %d", rule.Aggregate, rule.Aggregate.Apply(values))
+		}
+		for p := n.Start; p.X < n.End.X; p = p.Add(grid.East) {
+			ret.Kinds.Set(p, kind)
+			ret.Tooltips.Set(p, tooltip)
+		}
+	}
+
+	return ret
+}
+
+// Writes the schematic colored by ANSI escape sequences, followed by
+// a legend if requested.
+func (a *Annotated) WriteANSI(w io.Writer, legend bool) error {
+	var out strings.Builder
+	for y := 0; y < a.Schematic.Height(); y++ {
+		curr := CellEmpty
+		a.Schematic.Row(y)(func(p grid.Point, r rune) bool {
+			if kind := a.Kinds.Get(p); kind != curr {
+				out.WriteString(ansiColors[kind])
+				curr = kind
+			}
+			out.WriteRune(r)
+			return true
+		})
+		if curr != CellEmpty {
+			out.WriteString(ansiReset)
+		}
+		out.WriteByte('\n')
+	}
+	if legend {
+		out.WriteByte('\n')
+		for kind := CellNumber; kind <= CellGear; kind++ {
+			fmt.Fprintf(&out, "%s%s%s ", ansiColors[kind], cellKindDescriptions[kind], ansiReset)
+		}
+		fmt.Fprintf(&out, "(gears: %s)\n", a.Rule)
+	}
+	_, err := io.WriteString(w, out.String())
+
+	return err
+}
+
+// Writes the schematic as a standalone HTML page, followed by a legend if
+// requested. Non-empty cells are spans with their kind as class, their
+// coordinates in data-x and data-y and a description as title.
+func (a *Annotated) WriteHTML(w io.Writer, legend bool) error {
+	var out strings.Builder
+	out.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Day 3 schematic</title>\n<style>\n")
+	out.WriteString(htmlStyle)
+	out.WriteString("</style>\n</head>\n<body>\n<pre>\n")
+	a.Schematic.All()(func(p grid.Point, r rune) bool {
+		kind := a.Kinds.Get(p)
+		if kind == CellEmpty {
+			out.WriteString(html.EscapeString(string(r)))
+		} else {
+			fmt.Fprintf(&out, "<span class=\"%s\" data-x=\"%d\" data-y=\"%d\" title=\"%s\">%s</span>",
+				kind, p.X, p.Y, html.EscapeString(a.Tooltips.Get(p)), html.EscapeString(string(r)))
+		}
+		if p.X == a.Schematic.Width()-1 {
+			out.WriteByte('\n')
+		}
+		return true
+	})
+	out.WriteString("</pre>\n")
+	if legend {
+		out.WriteString("<p>")
+		for kind := CellNumber; kind <= CellGear; kind++ {
+			fmt.Fprintf(&out, "<span class=\"%s\">%s</span> ", kind, cellKindDescriptions[kind])
+		}
+		fmt.Fprintf(&out, "(gears: %s)</p>\n", html.EscapeString(a.Rule.String()))
+	}
+	out.WriteString("</body>\n</html>\n")
+	_, err := io.WriteString(w, out.String())
+
+	return err
+}
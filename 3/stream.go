@@ -0,0 +1,216 @@
+package day3
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/prki/aoc2023/lib/grid"
+	"github.com/prki/aoc2023/lib/input"
+)
+
+// Part number found by SolveStream.
+type PartNumber struct {
+	Value   int
+	Start   grid.Point
+	End     grid.Point // just after the last digit
+	Symbols []Symbol   // adjacent symbols, row by row
+}
+
+// Results of SolveStream.
+type StreamTotals struct {
+	Rows     int
+	PartSum  int
+	GearSums []int // by rule of StreamOptions.Rules
+}
+
+// Options of SolveStream.
+type StreamOptions struct {
+	// Rules of gears to sum, PuzzleGearRule if empty.
+	Rules []GearRule
+	// Called for each part number as soon as the row below it is read, in
+	// reading order.
+	OnPart func(part PartNumber)
+	// Called for each gear matched by Rules[rule] as soon as the row below
+	// its adjacent numbers is read, row by row.
+	OnGear func(rule int, gear Gear)
+}
+
+// Sliding window of the rows above, on and below the row being solved.
+// Rows are padded with '.' as needed.
+type window struct {
+	rows    [3][]rune
+	y       int                 // row of rows[1]
+	pending map[int]SymbolIndex // symbols of rows y-1 to y+1 with their numbers so far, by row
+}
+
+func (w *window) push(row []rune) {
+	w.rows[0], w.rows[1], w.rows[2] = w.rows[1], w.rows[2], row
+}
+
+// Returns the cell on x of rows[1+dy].
+func (w *window) at(x, dy int) rune {
+	row := w.rows[1+dy]
+	if x < 0 || x >= len(row) {
+		return '.'
+	}
+
+	return row[x]
+}
+
+// Returns part numbers of rows[1] and registers them with their symbols.
+func (w *window) parts() []PartNumber {
+	var ret []PartNumber
+
+	row := w.rows[1]
+	for x := 0; x < len(row); x++ {
+		if !isDigit(row[x]) {
+			continue
+		}
+		start := x
+		for x < len(row) && isDigit(row[x]) {
+			x++
+		}
+		var symbols []Symbol
+		for dy := -1; dy <= 1; dy++ {
+			for sx := start - 1; sx <= x; sx++ {
+				if r := w.at(sx, dy); isSymbol(r) {
+					symbols = append(symbols, Symbol{Symbol: r, Coords: grid.Point{X: sx, Y: w.y + dy}})
+				}
+			}
+		}
+		if len(symbols) == 0 {
+			continue
+		}
+		num, _ := strconv.Atoi(string(row[start:x]))
+		for _, symbol := range symbols {
+			idx, ok := w.pending[symbol.Coords.Y]
+			if !ok {
+				idx = make(SymbolIndex)
+				w.pending[symbol.Coords.Y] = idx
+			}
+			idx.Register(symbol, num)
+		}
+		ret = append(ret, PartNumber{
+			Value:   num,
+			Start:   grid.Point{X: start, Y: w.y},
+			End:     grid.Point{X: x, Y: w.y},
+			Symbols: symbols,
+		})
+	}
+
+	return ret
+}
+
+// Removes symbols of row y, which have all their numbers registered, and
+// returns them ordered by column together with their numbers.
+func (w *window) complete(y int) ([]Symbol, SymbolIndex) {
+	idx := w.pending[y]
+	delete(w.pending, y)
+
+	ret := make([]Symbol, 0, len(idx))
+	for symbol := range idx {
+		ret = append(ret, symbol)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Coords.X < ret[j].Coords.X })
+
+	return ret, idx
+}
+
+// Solves a schematic read row by row, keeping only three rows in memory.
+// Part numbers and gears are reported by callbacks of opts as soon as they
+// are known, so that schematics of any height can be solved. Rows may be ragged, missing cells count as '.'.
+// Trailing blank rows are ignored, the same as by LoadSchematic.
+func SolveStream(r io.Reader, opts StreamOptions) (StreamTotals, error) {
+	rules := opts.Rules
+	if len(rules) == 0 {
+		rules = []GearRule{PuzzleGearRule}
+	}
+	ret := StreamTotals{GearSums: make([]int, len(rules))}
+	w := window{pending: make(map[int]SymbolIndex)}
+
+	// Solves the row in the middle of the window and completes gears above it.
+	solveRow := func() {
+		for _, part := range w.parts() {
+			ret.PartSum += part.Value
+			if opts.OnPart != nil {
+				opts.OnPart(part)
+			}
+		}
+		symbols, idx := w.complete(w.y - 1)
+		for _, symbol := range symbols {
+			nums := idx[symbol]
+			for i, rule := range rules {
+				if !rule.Matches(symbol, nums) {
+					continue
+				}
+				gear := Gear{Symbol: symbol, Numbers: nums, Value: rule.Aggregate.Apply(nums)}
+				ret.GearSums[i] += gear.Value
+				if opts.OnGear != nil {
+					opts.OnGear(i, gear)
+				}
+			}
+		}
+		w.y++
+	}
+
+	pushRow := func(row []rune) {
+		ret.Rows++
+		w.push(row)
+		if ret.Rows >= 2 {
+			solveRow()
+		}
+	}
+	width := 0
+	addRow := func(line string) {
+		if ret.Rows == 0 {
+			width = utf8.RuneCountInString(line)
+		} else if rowWidth := utf8.RuneCountInString(line); rowWidth != width {
+			logger.Warn("ragged schematic row padded with '.'", "line", ret.Rows+1, "width", rowWidth, "expected", width)
+		}
+		pushRow([]rune(line))
+	}
+
+	// Blank rows are held back until a row follows them, trailing ones are
+	// dropped as by LoadSchematic. Only the first and last row of a run can
+	// touch a digit, rows between them are replayed as padding.
+	blankCount := 0
+	var firstBlank, lastBlank string
+	scanner := input.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if len(strings.TrimSpace(line)) == 0 {
+			if blankCount == 0 {
+				firstBlank = line
+			}
+			lastBlank = line
+			blankCount++
+			continue
+		}
+		if blankCount > 0 {
+			addRow(firstBlank)
+			for i := 2; i < blankCount; i++ {
+				pushRow(nil)
+			}
+			if blankCount > 1 {
+				addRow(lastBlank)
+			}
+			blankCount = 0
+		}
+		addRow(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return ret, err
+	}
+	if ret.Rows > 0 {
+		w.push(nil)
+		solveRow()
+		// completes the last row
+		w.push(nil)
+		solveRow()
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,95 @@
+package day4
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// Instances of each card once all copies are won, counted by dynamic
+// programming: every instance of a card wins one copy of each of the cards
+// with the following CntWinningNumbers ids. Counts grow exponentially with
+// the number of cards, so they are big integers.
+type CardCounts struct {
+	Games     []ScratchcardGame
+	Instances []*big.Int // by index in Games, including the original card
+	Total     *big.Int
+
+	sources [][]int // indices of the cards which won copies, by index in Games
+}
+
+// Source of copies of a card.
+type CopySource struct {
+	Card   int // id of the winning card
+	Copies *big.Int
+}
+
+// Counts instances of the games in O(cards × matches). Cards are matched by
+// their ids, so they may be out of order. Copies of missing ids are dropped,
+// cards listed more than once all win the copies. Instances of a card are
+// final once all cards with lower ids are processed.
+func CountCards(games []ScratchcardGame) CardCounts {
+	ret := CardCounts{
+		Games:     games,
+		Instances: make([]*big.Int, len(games)),
+		Total:     new(big.Int),
+		sources:   make([][]int, len(games)),
+	}
+	for i := range ret.Instances {
+		ret.Instances[i] = big.NewInt(1)
+	}
+
+	byId := make(map[int][]int)
+	order := make([]int, len(games))
+	for i, game := range games {
+		byId[game.GameId] = append(byId[game.GameId], i)
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return games[order[a]].GameId < games[order[b]].GameId })
+
+	for _, i := range order {
+		game := games[i]
+		for id := game.GameId + 1; id <= game.GameId+game.CntWinningNumbers; id++ {
+			for _, j := range byId[id] {
+				ret.Instances[j].Add(ret.Instances[j], ret.Instances[i])
+				ret.sources[j] = append(ret.sources[j], i)
+			}
+		}
+		ret.Total.Add(ret.Total, ret.Instances[i])
+	}
+
+	return ret
+}
+
+// Returns the cards which won copies of the card on index i ordered by id,
+// with the number of copies each of them won.
+func (c CardCounts) Sources(i int) []CopySource {
+	ret := make([]CopySource, len(c.sources[i]))
+	for s, k := range c.sources[i] {
+		ret[s] = CopySource{Card: c.Games[k].GameId, Copies: c.Instances[k]}
+	}
+
+	return ret
+}
+
+// Writes a line per card breaking down its instances by their origin, e.g.
+//
+//	Card 4: 8 instances = 1 original + 1 from card 1 + 2 from card 2 + 4 from card 3
+func (c CardCounts) Explain(w io.Writer) error {
+	for i, game := range c.Games {
+		var line strings.Builder
+		fmt.Fprintf(&line, "Card %d: %s instances = 1 original", game.GameId, c.Instances[i])
+		for _, source := range c.Sources(i) {
+			fmt.Fprintf(&line, " + %s from card %d", source.Copies, source.Card)
+		}
+		line.WriteByte('\n')
+		if _, err := io.WriteString(w, line.String()); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "Total: %s cards\n", c.Total)
+
+	return err
+}
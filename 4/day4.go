@@ -0,0 +1,276 @@
+package day4
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/prki/aoc2023/lib/logging"
+)
+
+var logger = logging.Day(4)
+
+type ScratchcardGame struct {
+	WinningNumbers    map[int]int // winning number -> times listed
+	SelectedNumbers   []int
+	PointsAwarded     int
+	CntWinningNumbers int // distinct selected numbers which are winning
+	GameId            int // card id as given in the input
+	Line              int // 1-based line of the card in the input
+	// Problems of the card which don't prevent solving it, see Validate.
+	Issues input.ErrorList
+}
+
+// Selected numbers listed more than once count once.
+func (g *ScratchcardGame) CalculatePoints() {
+	calcMap := make(map[int]int)
+	for _, selNum := range g.SelectedNumbers {
+		_, ok := g.WinningNumbers[selNum]
+		if ok {
+			calcMap[selNum] += 1
+		}
+	}
+
+	winningCnt := len(calcMap)
+	if winningCnt == 0 {
+		g.PointsAwarded = 0
+	} else {
+		g.PointsAwarded = int(math.Pow(2, float64(winningCnt-1))) // -1 - starting score is "1" == 2^0
+	}
+
+	g.CntWinningNumbers = winningCnt
+}
+
+// Parses space separated numbers. Invalid tokens are skipped, they are
+// returned as issues together with numbers listed more than once.
+func parseNumbers(field input.Field, kind string) ([]int, input.ErrorList) {
+	var ret []int
+	var issues input.ErrorList
+
+	seen := make(map[int]bool)
+	for _, token := range field.Fields() {
+		num, err := strconv.Atoi(token.Text)
+		if err != nil {
+			issues.Add(token.Errorf("skipped invalid %s number %q", kind, token.Text))
+			continue
+		}
+		if seen[num] {
+			issues.Add(token.Errorf("%s number %d listed more than once", kind, num))
+		}
+		seen[num] = true
+		ret = append(ret, num)
+	}
+
+	return ret, issues
+}
+
+func parseWinningNumbers(field input.Field) (map[int]int, input.ErrorList) {
+	ret := make(map[int]int)
+
+	nums, issues := parseNumbers(field, "winning")
+	for _, num := range nums {
+		ret[num] += 1
+	}
+
+	return ret, issues
+}
+
+func parseSelectedNumbers(field input.Field) ([]int, input.ErrorList) {
+	return parseNumbers(field, "selected")
+}
+
+// Parses a "Card <id>: <winning numbers> | <selected numbers>" line.
+func parseGame(inputLine string) (ScratchcardGame, error) {
+	var game ScratchcardGame
+	var errs input.ErrorList
+
+	line := input.NewLine(inputLine)
+	gameIdSplit := line.Split(":")
+	if len(gameIdSplit) != 2 {
+		errs.Add(line.Errorf("expected exactly one ':' after card id"))
+		return game, errs.Err()
+	}
+	gameIdFields := gameIdSplit[0].Fields()
+	if len(gameIdFields) != 2 {
+		errs.Add(gameIdSplit[0].Errorf("expected \"Card <id>\""))
+		return game, errs.Err()
+	}
+	gameId, err := strconv.Atoi(gameIdFields[1].Text)
+	if err != nil {
+		errs.Add(gameIdFields[1].Errorf("invalid card id %q", gameIdFields[1].Text))
+		return game, errs.Err()
+	}
+	game.GameId = gameId
+
+	numberLine := gameIdSplit[1].Split("|")
+	if len(numberLine) != 2 {
+		errs.Add(gameIdSplit[1].Errorf("expected winning and selected numbers separated by '|'"))
+		return game, errs.Err()
+	}
+	var winningIssues, selectedIssues input.ErrorList
+	game.WinningNumbers, winningIssues = parseWinningNumbers(numberLine[0])
+	game.SelectedNumbers, selectedIssues = parseSelectedNumbers(numberLine[1])
+	game.Issues = append(winningIssues, selectedIssues...)
+
+	return game, errs.Err()
+}
+
+// Blank lines are skipped. All parse errors of the input are returned together.
+func initializeGames(inputLines []string) ([]ScratchcardGame, error) {
+	var ret []ScratchcardGame
+	var errs input.ErrorList
+
+	for i, line := range inputLines {
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+		game, err := parseGame(line)
+		if err != nil {
+			errs.AddLine(i+1, line, err)
+			continue
+		}
+		game.Line = i + 1
+		for _, issue := range game.Issues {
+			issue.Line = game.Line
+		}
+
+		ret = append(ret, game)
+	}
+
+	return ret, errs.Err()
+}
+
+// Returns issues of the cards ordered by line: invalid and repeated numbers,
+// card ids repeated, out of order or missing, and cards with more or fewer
+// numbers than the first one. Missing ids are reported on the card following
+// them.
+func Validate(games []ScratchcardGame) input.ErrorList {
+	var ret input.ErrorList
+
+	if len(games) == 0 {
+		return ret
+	}
+
+	first := games[0]
+	prevId := 0
+	lines := make(map[int]int) // card id -> line of its first occurrence
+	for _, g := range games {
+		ret = append(ret, g.Issues...)
+
+		if _, ok := lines[g.GameId]; ok {
+			ret.Add(&input.ParseError{Line: g.Line, Reason: fmt.Sprintf("card %d listed more than once", g.GameId)})
+		} else {
+			lines[g.GameId] = g.Line
+			if g.GameId < prevId {
+				ret.Add(&input.ParseError{Line: g.Line, Reason: fmt.Sprintf("card %d out of order after card %d", g.GameId, prevId)})
+			}
+		}
+		prevId = max(prevId, g.GameId)
+
+		if g.winningCount() != first.winningCount() || len(g.SelectedNumbers) != len(first.SelectedNumbers) {
+			ret.Add(&input.ParseError{
+				Line: g.Line,
+				Reason: fmt.Sprintf("card %d has %d winning and %d selected numbers, card %d has %d and %d",
+					g.GameId, g.winningCount(), len(g.SelectedNumbers), first.GameId, first.winningCount(), len(first.SelectedNumbers)),
+			})
+		}
+	}
+
+	ids := make([]int, 0, len(lines))
+	for id := range lines {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	nextId := 1
+	for _, id := range ids {
+		if id == nextId+1 {
+			ret.Add(&input.ParseError{Line: lines[id], Reason: fmt.Sprintf("card %d missing", nextId)})
+		} else if id > nextId+1 {
+			ret.Add(&input.ParseError{Line: lines[id], Reason: fmt.Sprintf("cards %d to %d missing", nextId, id-1)})
+		}
+		nextId = id + 1
+	}
+	sort.SliceStable(ret, func(i, j int) bool { return ret[i].Line < ret[j].Line })
+
+	return ret
+}
+
+// Returns the number of winning numbers as listed, including repeated ones.
+func (g *ScratchcardGame) winningCount() int {
+	ret := 0
+	for _, count := range g.WinningNumbers {
+		ret += count
+	}
+
+	return ret
+}
+
+// Parses the cards. Issues found by Validate are logged as warnings, cards
+// are solved by their ids regardless of their order.
+func ParseScratchcardGames(inputLines []string) ([]ScratchcardGame, error) {
+	games, err := initializeGames(inputLines)
+	if err != nil {
+		return nil, err
+	}
+	for _, issue := range Validate(games) {
+		logger.Warn("invalid scratchcard", "line", issue.Line, "reason", issue.Reason)
+	}
+	for i := 0; i < len(games); i++ {
+		games[i].CalculatePoints()
+	}
+
+	return games, nil
+}
+
+// Parses the cards, rejecting the input if Validate finds any issue.
+func ParseScratchcardGamesStrict(inputLines []string) ([]ScratchcardGame, error) {
+	games, err := initializeGames(inputLines)
+	if err != nil {
+		return nil, err
+	}
+	if err := Validate(games).Err(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(games); i++ {
+		games[i].CalculatePoints()
+	}
+
+	return games, nil
+}
+
+func Part1(r io.Reader) (string, error) {
+	puzzleInput, err := input.ReadLines(r)
+	if err != nil {
+		return "", err
+	}
+	games, err := ParseScratchcardGames(puzzleInput)
+	if err != nil {
+		return "", err
+	}
+	logger.Debug("cards parsed", "count", len(games))
+	solution := 0
+	for i := 0; i < len(games); i++ {
+		solution += games[i].PointsAwarded
+	}
+
+	return strconv.Itoa(solution), nil
+}
+
+func Part2(r io.Reader) (string, error) {
+	puzzleInput, err := input.ReadLines(r)
+	if err != nil {
+		return "", err
+	}
+	games, err := ParseScratchcardGames(puzzleInput)
+	if err != nil {
+		return "", err
+	}
+	counts := CountCards(games)
+	logger.Debug("cards counted", "cards", len(games), "total", counts.Total)
+
+	return counts.Total.String(), nil
+}
@@ -0,0 +1,112 @@
+package day4
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var example = []string{
+	"Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53",
+	"Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 24 19",
+	"Card 3:  1 21 53 59 44 | 69 82 63 72 16 21 14  1",
+	"Card 4: 41 92 73 84 69 | 59 84 76 51 58  5 54 83",
+	"Card 5: 87 83 26 28 32 | 88 30 70 12 93 22 82 36",
+	"Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11",
+}
+
+func TestCountCards(t *testing.T) {
+	games, err := ParseScratchcardGames(example)
+	assert.NoError(t, err)
+	counts := CountCards(games)
+	assert.Equal(t, "30", counts.Total.String())
+	var instances []int64
+	for _, n := range counts.Instances {
+		instances = append(instances, n.Int64())
+	}
+	assert.Equal(t, []int64{1, 2, 4, 8, 14, 1}, instances)
+	assert.Equal(t, []CopySource{
+		{Card: 1, Copies: big.NewInt(1)},
+		{Card: 2, Copies: big.NewInt(2)},
+		{Card: 3, Copies: big.NewInt(4)},
+	}, counts.Sources(3))
+
+	var out bytes.Buffer
+	assert.NoError(t, counts.Explain(&out))
+	lines := strings.Split(out.String(), "\n")
+	assert.Equal(t, "Card 4: 8 instances = 1 original + 1 from card 1 + 2 from card 2 + 4 from card 3", lines[3])
+	assert.Equal(t, "Total: 30 cards", lines[6])
+}
+
+// Every card winning copies of all following cards doubles the total.
+func TestCountCardsHuge(t *testing.T) {
+	games := make([]ScratchcardGame, 100)
+	for i := range games {
+		games[i].GameId = i + 1
+		games[i].CntWinningNumbers = len(games)
+	}
+	counts := CountCards(games)
+	expected := new(big.Int).Lsh(big.NewInt(1), 100)
+	assert.Equal(t, expected.Sub(expected, big.NewInt(1)), counts.Total)
+}
+
+func TestValidate(t *testing.T) {
+	lines := []string{
+		"Card 1: 41 48 83 86 17 | 83 86  6 31 17  9 48 53",
+		"Card 3:  1 21 53 59 21 | 69 82 63 72 16 21 14  1",
+		"Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 x 19",
+		"",
+		"Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11 11",
+	}
+	games, err := ParseScratchcardGames(lines)
+	assert.NoError(t, err)
+
+	var issues []string
+	for _, issue := range Validate(games) {
+		issues = append(issues, issue.Error())
+	}
+	assert.Equal(t, []string{
+		`line 2, column 21: winning number 21 listed more than once in "Card 3:  1 21 53 59 21 | 69 82 63 72 16 21 14  1"`,
+		`line 3, column 44: skipped invalid selected number "x" in "Card 2: 13 32 20 16 61 | 61 30 68 82 17 32 x 19"`,
+		`line 3: card 2 out of order after card 3`,
+		`line 3: card 2 has 5 winning and 7 selected numbers, card 1 has 5 and 8`,
+		`line 5, column 50: selected number 11 listed more than once in "Card 6: 31 18 13 56 72 | 74 77 10 23 35 67 36 11 11"`,
+		`line 5: card 6 has 5 winning and 9 selected numbers, card 1 has 5 and 8`,
+		`line 5: cards 4 to 5 missing`,
+	}, issues)
+
+	games, err = ParseScratchcardGames([]string{example[2], example[0], example[0]})
+	assert.NoError(t, err)
+	issues = nil
+	for _, issue := range Validate(games) {
+		issues = append(issues, issue.Error())
+	}
+	assert.Equal(t, []string{
+		`line 1: card 2 missing`,
+		`line 2: card 1 out of order after card 3`,
+		`line 3: card 1 listed more than once`,
+	}, issues)
+
+	_, err = ParseScratchcardGamesStrict(lines)
+	assert.Error(t, err)
+	_, err = ParseScratchcardGamesStrict(example)
+	assert.NoError(t, err)
+}
+
+// Cards are matched by ids, not by their position in the input.
+func TestCountCardsShuffled(t *testing.T) {
+	shuffled := []string{example[4], example[1], example[5], example[0], example[3], example[2]}
+	games, err := ParseScratchcardGames(shuffled)
+	assert.NoError(t, err)
+	counts := CountCards(games)
+	assert.Equal(t, "30", counts.Total.String())
+	assert.Equal(t, int64(14), counts.Instances[0].Int64()) // card 5
+	assert.Equal(t, []CopySource{
+		{Card: 1, Copies: big.NewInt(1)},
+		{Card: 3, Copies: big.NewInt(4)},
+		{Card: 4, Copies: big.NewInt(8)},
+	}, counts.Sources(0))
+}
@@ -1,15 +1,17 @@
-package main
+package day5
 
 import (
-	"bufio"
-	"fmt"
-	"log"
+	"io"
 	"math"
-	"os"
 	"strconv"
 	"strings"
+
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/prki/aoc2023/lib/logging"
 )
 
+var logger = logging.Day(5)
+
 type Interval struct {
 	Start uint64
 	End   uint64
@@ -64,54 +66,49 @@ func (am *AlmanacMap) GetDestinationID(source uint64) uint64 {
 	return ret
 }
 
-func ReadInput(path string) []string {
-	ret := make([]string, 0)
-	fil, err := os.Open(path)
-	if err != nil {
-		log.Fatalf("[ERROR] Can't open file %s\n", path)
-	}
-	defer fil.Close()
-
-	scanner := bufio.NewScanner(fil)
-	for scanner.Scan() {
-		ret = append(ret, scanner.Text())
-	}
-
-	return ret
-}
-
-func parseSeeds(line string) []uint64 {
+// Parses the "seeds: 79 14 55 13" line.
+func parseSeeds(line string) ([]uint64, error) {
 	var ret []uint64
+	var errs input.ErrorList
 
-	seedIds := strings.Fields(strings.Split(line, ":")[1])
+	seedsSplit := input.NewLine(line).Split(":")
+	if len(seedsSplit) != 2 || seedsSplit[0].Text != "seeds" {
+		errs.Add(seedsSplit[0].Errorf("expected \"seeds: <id> ...\""))
+		return nil, errs.Err()
+	}
+	seedIds := seedsSplit[1].Fields()
 	for i := 0; i < len(seedIds); i++ {
 		//idInt, err := strconv.Atoi(seedIds[i])
-		idInt, err := strconv.ParseUint(seedIds[i], 10, 64)
+		idInt, err := strconv.ParseUint(seedIds[i].Text, 10, 64)
 		if err != nil {
-			log.Fatal("[ERROR] Cant parse seeds:", err)
+			errs.Add(seedIds[i].Errorf("invalid seed id %q", seedIds[i].Text))
+			continue
 		}
 		ret = append(ret, idInt)
 	}
 
-	return ret
+	return ret, errs.Err()
 }
 
-func parseMappingLine(inputLine string) MappingLine {
-	fields := strings.Fields(inputLine)
-	//destId, err := strconv.Atoi(fields[0])
-	destId, err := strconv.ParseUint(fields[0], 10, 64)
-	if err != nil {
-		log.Fatal("error parsing mapping line:", err)
-	}
-	//srcId, err := strconv.Atoi(fields[1])
-	srcId, err := strconv.ParseUint(fields[1], 10, 64)
-	if err != nil {
-		log.Fatal("error parsing mapping line:", err)
+// Parses a "<destination start> <source start> <range>" line.
+func parseMappingLine(inputLine string) (MappingLine, error) {
+	var errs input.ErrorList
+
+	line := input.NewLine(inputLine)
+	fields := line.Fields()
+	if len(fields) != 3 {
+		errs.Add(line.Errorf("expected 3 numbers in mapping line, got %d", len(fields)))
+		return MappingLine{}, errs.Err()
 	}
-	//mapRange, err := strconv.Atoi(fields[2])
-	mapRange, err := strconv.ParseUint(fields[2], 10, 64)
-	if err != nil {
-		log.Fatal("error parsing mapping line:", err)
+
+	var nums [3]uint64
+	for i, field := range fields {
+		num, err := strconv.ParseUint(field.Text, 10, 64)
+		if err != nil {
+			errs.Add(field.Errorf("invalid number %q", field.Text))
+			continue
+		}
+		nums[i] = num
 	}
 
 	/*if destId < 0 || srcId < 0 || mapRange < 0 {
@@ -121,45 +118,59 @@ func parseMappingLine(inputLine string) MappingLine {
 	*/
 
 	ret := MappingLine{
-		SourceStart:      srcId,
-		DestinationStart: destId,
-		Range:            mapRange,
+		SourceStart:      nums[1],
+		DestinationStart: nums[0],
+		Range:            nums[2],
 	}
 
-	return ret
+	return ret, errs.Err()
 }
 
-func ParseInput(inputLines []string) ([]uint64, []AlmanacMap) {
-	almanacMaps := make([]AlmanacMap, 7, 7)
-	for i := 0; i < len(almanacMaps); i++ {
-		almanacMaps[i].Map = make(map[uint64]uint64)
+// Input consists of blocks separated by blank lines. First block contains
+// the seeds, each following block is one almanac map with a header line
+// (e.g. "seed-to-soil map:") followed by mapping lines.
+// All parse errors of the input are returned together.
+func ParseInput(inputLines []string) ([]uint64, []AlmanacMap, error) {
+	var errs input.ErrorList
+
+	blocks := input.Blocks(inputLines)
+	if len(blocks) == 0 {
+		errs.Add(&input.ParseError{Line: 1, Reason: "missing seeds"})
+		return nil, nil, errs.Err()
 	}
-	var seeds []uint64
-	almanacIdx := 0
-
-	seeds = parseSeeds(inputLines[0])
-	for i := 1; i < len(inputLines); i++ {
-		if strings.Contains(inputLines[i], "map:") {
-			for {
-				i += 1
-				if i >= len(inputLines) || len(inputLines[i]) == 0 { // newline - len == 0 it seems
-					almanacIdx += 1
-					break
-				}
-				mappingLine := parseMappingLine(inputLines[i])
-				almanacMaps[almanacIdx].Mappings = append(almanacMaps[almanacIdx].Mappings, mappingLine)
+	seeds, err := parseSeeds(blocks[0].Lines[0])
+	if err != nil {
+		errs.AddLine(blocks[0].Line, blocks[0].Lines[0], err)
+	}
+
+	var almanacMaps []AlmanacMap
+	for _, block := range blocks[1:] {
+		almanacMap := AlmanacMap{
+			Map: make(map[uint64]uint64),
+		}
+		header := input.NewLine(block.Lines[0])
+		if !strings.HasSuffix(header.Text, "map:") {
+			errs.AddLine(block.Line, header.Text, header.Errorf("expected \"<source>-to-<destination> map:\" header"))
+		}
+		for i, line := range block.Lines[1:] { // skip "x-to-y map:" header
+			mappingLine, err := parseMappingLine(line)
+			if err != nil {
+				errs.AddLine(block.Line+1+i, line, err)
+				continue
 			}
+			almanacMap.Mappings = append(almanacMap.Mappings, mappingLine)
 		}
+		almanacMaps = append(almanacMaps, almanacMap)
 	}
 
 	// Uncomment for naive solution enablement
 	/*for i := 0; i < len(almanacMaps); i++ {
-		fmt.Println("populating map")
+		logger.Debug("populating map", "map", i)
 		almanacMaps[i].PopulateMap()
 	}
 	*/
 
-	return seeds, almanacMaps
+	return seeds, almanacMaps, errs.Err()
 }
 
 func MaxU64(a, b uint64) uint64 {
@@ -225,13 +236,13 @@ func MapToDestinationRange(srcInt Interval, mapping MappingLine) Interval {
 		End:   uint64(end),
 	}
 
-	//fmt.Println("Source interval", srcInt, "mapped to:", ret)
+	logging.Trace(logger, "source interval mapped", "source", srcInt, "destination", ret)
 
 	return ret
 }
 
 func FilterOverlaps(int Interval, ints []Interval) []Interval {
-	//fmt.Println("Filtering interval", int, "from overlap subsets", ints)
+	logging.Trace(logger, "filtering interval from overlap subsets", "interval", int, "overlaps", ints)
 	toFilter := []Interval{int}
 	for i := 0; i < len(ints); i++ {
 		var tmp []Interval
@@ -244,7 +255,7 @@ func FilterOverlaps(int Interval, ints []Interval) []Interval {
 		tmp = nil
 	}
 
-	//fmt.Println("Filtered:", toFilter)
+	logging.Trace(logger, "filtered interval", "interval", int, "filtered", toFilter)
 
 	return toFilter
 }
@@ -297,17 +308,17 @@ func Solution2(seeds []uint64, almanacMaps []AlmanacMap) uint64 {
 	currSrcInts := seedIntervals
 	for i := 0; i < len(almanacMaps); i++ {
 		for j := 0; j < len(currSrcInts); j++ {
-			//fmt.Println("Generating possible intervals for source int:", currSrcInts[j])
+			logging.Trace(logger, "generating possible intervals", "map", i, "source", currSrcInts[j])
 			tmp := GeneratePossibleDestIntervals(currSrcInts[j], almanacMaps[i])
 			destIntervals = append(destIntervals, tmp...)
 		}
 		currSrcInts = nil
 		currSrcInts = append(currSrcInts, destIntervals...)
 		destIntervals = nil
-		//fmt.Println("Discovered dest intervals:", currSrcInts)
+		logger.Debug("discovered destination intervals", "map", i, "intervals", currSrcInts)
 	}
 
-	//fmt.Println("Dest intervals:", currSrcInts)
+	logger.Debug("destination intervals", "intervals", currSrcInts)
 	ret = math.MaxUint64
 	for i := 0; i < len(currSrcInts); i++ {
 		if currSrcInts[i].Start < ret {
@@ -318,28 +329,52 @@ func Solution2(seeds []uint64, almanacMaps []AlmanacMap) uint64 {
 	return ret
 }
 
-func main() {
-	input := ReadInput("./input.txt")
-	seeds, almanacMaps := ParseInput(input)
-	fmt.Println("Input parsed successfully")
-	fmt.Println("Seeds:", seeds)
+func Solution1(seeds []uint64, almanacMaps []AlmanacMap) uint64 {
 	var minLocation uint64 = math.MaxUint64
 	for _, seed := range seeds {
-		//fmt.Println("Seed mapping for seed:", seed)
-		//fmt.Println("----------")
-		//fmt.Println("Calculating seed mappings for seed:", seed)
+		logger.Debug("calculating seed mappings", "seed", seed)
 		tmp := seed
 		for i := 0; i < len(almanacMaps); i++ {
 			tmp = almanacMaps[i].GetDestinationID(tmp)
-			//fmt.Println("Almanac map pointed to id:", tmp)
+			logging.Trace(logger, "almanac map pointed to id", "seed", seed, "map", i, "id", tmp)
 		}
 		if tmp < minLocation {
 			minLocation = tmp
 		}
 	}
 
-	fmt.Println("Solution 1:", minLocation)
+	return minLocation
+}
+
+func parseAlmanac(r io.Reader) ([]uint64, []AlmanacMap, error) {
+	lines, err := input.ReadLines(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	seeds, almanacMaps, err := ParseInput(lines)
+	if err != nil {
+		return nil, nil, err
+	}
+	logger.Info("input parsed successfully", "seeds", len(seeds), "maps", len(almanacMaps))
+	logger.Debug("seeds parsed", "seeds", seeds)
+
+	return seeds, almanacMaps, nil
+}
+
+func Part1(r io.Reader) (string, error) {
+	seeds, almanacMaps, err := parseAlmanac(r)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatUint(Solution1(seeds, almanacMaps), 10), nil
+}
+
+func Part2(r io.Reader) (string, error) {
+	seeds, almanacMaps, err := parseAlmanac(r)
+	if err != nil {
+		return "", err
+	}
 
-	solution2 := Solution2(seeds, almanacMaps)
-	fmt.Println("Solution 2:", solution2)
+	return strconv.FormatUint(Solution2(seeds, almanacMaps), 10), nil
 }
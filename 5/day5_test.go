@@ -1,8 +1,9 @@
-package main
+package day5
 
 import (
 	"testing"
 
+	"github.com/prki/aoc2023/lib/input"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,3 +52,20 @@ func TestIntervalNonoverlap(t *testing.T) {
 	nonOverlaps = CalcIntervalNonoverlap(intB, intA)
 	assert.ElementsMatch(t, expected, nonOverlaps)
 }
+
+func TestParseInputErrors(t *testing.T) {
+	lines := []string{
+		"seeds: 79 x4 55",
+		"",
+		"seed-to-soil map:",
+		"50 98",
+		"52 50 -48",
+	}
+	_, _, err := ParseInput(lines)
+	var errs input.ErrorList
+	assert.ErrorAs(t, err, &errs)
+	assert.Len(t, errs, 3)
+	assert.Equal(t, input.ParseError{Line: 1, Column: 11, Input: lines[0], Reason: `invalid seed id "x4"`}, *errs[0])
+	assert.Equal(t, input.ParseError{Line: 4, Column: 1, Input: lines[3], Reason: `expected 3 numbers in mapping line, got 2`}, *errs[1])
+	assert.Equal(t, input.ParseError{Line: 5, Column: 7, Input: lines[4], Reason: `invalid number "-48"`}, *errs[2])
+}
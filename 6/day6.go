@@ -0,0 +1,226 @@
+package day6
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/prki/aoc2023/lib/logging"
+)
+
+var logger = logging.Day(6)
+
+type BoatRace struct {
+	RecordTime     int
+	RecordDistance int
+}
+
+type Simulation struct {
+	TimeHeld        int
+	Velocity        int
+	DistanceCovered int
+}
+
+// Number fields of a single input line. Line number is kept for error reporting.
+type raceLine struct {
+	Line   int
+	Fields []input.Field
+}
+
+func (rl raceLine) errorf(field input.Field, format string, args ...any) *input.ParseError {
+	err := field.Errorf(format, args...)
+	err.Line = rl.Line
+
+	return err
+}
+
+// Splits the "Time: ..." and "Distance: ..." lines into their number fields.
+func parseRaceLines(inputLines []string) (raceLine, raceLine, error) {
+	var errs input.ErrorList
+
+	var lines []raceLine
+	for i, line := range inputLines {
+		lineFields := input.NewLine(line).Fields()
+		if len(lineFields) > 0 {
+			lines = append(lines, raceLine{Line: i + 1, Fields: lineFields})
+		}
+	}
+	if len(lines) != 2 {
+		errs.Add(&input.ParseError{Line: 1, Reason: fmt.Sprintf("expected \"Time:\" and \"Distance:\" lines, got %d lines", len(lines))})
+		return raceLine{}, raceLine{}, errs.Err()
+	}
+
+	for i, header := range []string{"Time:", "Distance:"} {
+		if lines[i].Fields[0].Text != header {
+			errs.Add(lines[i].errorf(lines[i].Fields[0], "expected %q", header))
+		}
+		lines[i].Fields = lines[i].Fields[1:]
+	}
+	times, distances := lines[0], lines[1]
+	if len(errs) == 0 && len(times.Fields) == 0 {
+		errs.Add(times.errorf(input.NewLine(inputLines[times.Line-1]), "expected at least one race"))
+	} else if len(errs) == 0 && len(times.Fields) != len(distances.Fields) {
+		errs.Add(&input.ParseError{
+			Line:   distances.Line,
+			Input:  inputLines[distances.Line-1],
+			Reason: fmt.Sprintf("expected %d distances, got %d", len(times.Fields), len(distances.Fields)),
+		})
+	}
+
+	return times, distances, errs.Err()
+}
+
+func ParseInput(inputLines []string) ([]BoatRace, error) {
+	var ret []BoatRace
+	var errs input.ErrorList
+
+	times, distances, err := parseRaceLines(inputLines)
+	if err != nil {
+		return nil, err
+	}
+
+	for ti := 0; ti < len(times.Fields); ti++ {
+		time, err := strconv.Atoi(times.Fields[ti].Text)
+		if err != nil {
+			errs.Add(times.errorf(times.Fields[ti], "invalid time %q", times.Fields[ti].Text))
+		}
+		distance, err := strconv.Atoi(distances.Fields[ti].Text)
+		if err != nil {
+			errs.Add(distances.errorf(distances.Fields[ti], "invalid distance %q", distances.Fields[ti].Text))
+		}
+		ret = append(ret, BoatRace{RecordTime: time, RecordDistance: distance})
+	}
+
+	return ret, errs.Err()
+}
+
+// Same as ParseInput, but all numbers on a line form a single number
+// (spaces are ignored).
+func ParseInput2(inputLines []string) (BoatRace, error) {
+	var ret BoatRace
+	var errs input.ErrorList
+
+	times, distances, err := parseRaceLines(inputLines)
+	if err != nil {
+		return ret, err
+	}
+
+	timeSubStrs := joinFields(times.Fields)
+	time, err := strconv.Atoi(timeSubStrs)
+	if err != nil {
+		errs.Add(times.errorf(times.Fields[0], "invalid time %q", timeSubStrs))
+	}
+
+	distSubStrs := joinFields(distances.Fields)
+	dist, err := strconv.Atoi(distSubStrs)
+	if err != nil {
+		errs.Add(distances.errorf(distances.Fields[0], "invalid distance %q", distSubStrs))
+	}
+
+	ret.RecordTime = time
+	ret.RecordDistance = dist
+
+	return ret, errs.Err()
+}
+
+func joinFields(fields []input.Field) string {
+	var sb strings.Builder
+	for _, field := range fields {
+		sb.WriteString(field.Text)
+	}
+
+	return sb.String()
+}
+
+func SimulateBoatrace(boatRace BoatRace) []Simulation {
+	var ret []Simulation
+
+	for i := 0; i < boatRace.RecordTime; i++ {
+		timeHeld := i
+		velocity := timeHeld * 1
+		timeLeft := boatRace.RecordTime - timeHeld
+		distance := velocity * timeLeft
+		ret = append(ret, Simulation{TimeHeld: timeHeld, Velocity: velocity, DistanceCovered: distance})
+	}
+
+	return ret
+}
+
+// Naive solution which creates simulation for each time + hold option.
+func Solution1(boatRaces []BoatRace) int {
+	product := 1
+	for _, boatRace := range boatRaces {
+		simulations := SimulateBoatrace(boatRace)
+		waysToWin := 0
+		for _, simul := range simulations {
+			if simul.DistanceCovered > boatRace.RecordDistance {
+				waysToWin += 1
+			}
+		}
+		if waysToWin > 1 {
+			product *= waysToWin
+		}
+	}
+
+	return product
+}
+
+// Naive solution creating simulations.
+// Proper solution should consider time as a function and analyze where the function is >= dist.
+// T_race - const
+// T_held
+// T_move
+// T_race = T_held + T_move
+// T_move = T_race - T_held
+// v = T_held
+// => s = v*t
+// => s = t_held * t_move
+// => s = (T_race - T_move) * T_move
+// since s (distance) has a set minimum, we can consider this as a problem of solving
+// the quadratic inequivality:
+// minDist < (T_race - T_move) * T_move
+// which would give us the the interval where the parabola has greater values than
+// the distance.
+// However, there is no reason to program this really, as the runtime is sufficient even
+// with a "naive" solution :)
+func Solution2(boatRace BoatRace) int {
+	logger.Debug("solving boat race", "time", boatRace.RecordTime, "distance", boatRace.RecordDistance)
+	waysToWin := 0
+
+	simulations := SimulateBoatrace(boatRace)
+	for i := 0; i < len(simulations); i++ {
+		if simulations[i].DistanceCovered > boatRace.RecordDistance {
+			waysToWin += 1
+		}
+	}
+
+	return waysToWin
+}
+
+func Part1(r io.Reader) (string, error) {
+	lines, err := input.ReadLines(r)
+	if err != nil {
+		return "", err
+	}
+	boatRaces, err := ParseInput(lines)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(Solution1(boatRaces)), nil
+}
+
+func Part2(r io.Reader) (string, error) {
+	lines, err := input.ReadLines(r)
+	if err != nil {
+		return "", err
+	}
+	boatRace, err := ParseInput2(lines)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(Solution2(boatRace)), nil
+}
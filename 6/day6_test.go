@@ -0,0 +1,35 @@
+package day6
+
+import (
+	"testing"
+
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInputErrors(t *testing.T) {
+	lines := []string{
+		"Time:      7  1x   30",
+		"Distance:  9  40  200",
+	}
+	_, err := ParseInput(lines)
+	var errs input.ErrorList
+	assert.ErrorAs(t, err, &errs)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, input.ParseError{Line: 1, Column: 15, Input: lines[0], Reason: `invalid time "1x"`}, *errs[0])
+
+	lines = []string{
+		"Time:      7  15   30",
+		"",
+		"Distanc:  9  40",
+	}
+	_, err = ParseInput2(lines)
+	assert.ErrorAs(t, err, &errs)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, input.ParseError{Line: 3, Column: 1, Input: lines[2], Reason: `expected "Distance:"`}, *errs[0])
+
+	lines[2] = "Distance:  9  40"
+	_, err = ParseInput(lines)
+	assert.ErrorAs(t, err, &errs)
+	assert.Equal(t, input.ParseError{Line: 3, Input: lines[2], Reason: `expected 3 distances, got 2`}, *errs[0])
+}
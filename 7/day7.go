@@ -1,15 +1,18 @@
-package main
+package day7
 
 import (
-	"bufio"
-	"fmt"
+	"io"
 	"log"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/prki/aoc2023/lib/logging"
 )
 
+var logger = logging.Day(7)
+
 var g_cardValueMap = map[string]int{
 	"2": 2,
 	"3": 3,
@@ -132,7 +135,7 @@ func (h *Hand) EvaluateScoreWithJokers() int {
 		return h.EvaluateScore()
 	}
 
-	fmt.Println("Evaluating hand:", h)
+	logger.Debug("evaluating hand with jokers", "hand", h)
 
 	maxScore := 0
 	for currJoker := 2; currJoker <= g_cardValueMapJoker["A"]; currJoker++ {
@@ -159,8 +162,7 @@ func (h *Hand) EvaluateScoreWithJokers() int {
 		}
 	}
 
-	// [DBG]
-	fmt.Println("Hand after promotion:", h)
+	logger.Debug("hand after promotion", "hand", h)
 
 	// Setting joker values to 1 so that comparison/sort behaves same as in solution1
 	for i := 0; i < len(h.Cards); i++ {
@@ -172,40 +174,56 @@ func (h *Hand) EvaluateScoreWithJokers() int {
 	return h.Score
 }
 
-func ReadInput(path string) []string {
-	ret := make([]string, 0)
-	fil, err := os.Open(path)
-	if err != nil {
-		log.Fatalf("[ERROR] Can't open file %s\n", path)
+// Parses a "<5 cards> <bid>" line.
+func parseHand(inputLine string) (Hand, error) {
+	hand := Hand{}
+	var errs input.ErrorList
+
+	line := input.NewLine(inputLine)
+	lineFields := line.Fields()
+	if len(lineFields) != 2 {
+		errs.Add(line.Errorf("expected \"<cards> <bid>\""))
+		return hand, errs.Err()
+	}
+	cards := lineFields[0]
+	if len(cards.Text) != 5 {
+		errs.Add(cards.Errorf("expected 5 cards, got %d", len(cards.Text)))
+	} else {
+		for j := 0; j < 5; j++ {
+			cardLabel := string(cards.Text[j])
+			value, ok := g_cardValueMap[cardLabel]
+			if !ok {
+				errs.Add(cards.Slice(j, j+1).Errorf("unknown card label %q", cardLabel))
+				continue
+			}
+			card := Card{Label: cardLabel, Value: value}
+			hand.Cards = append(hand.Cards, card)
+		}
 	}
-	defer fil.Close()
 
-	scanner := bufio.NewScanner(fil)
-	for scanner.Scan() {
-		ret = append(ret, scanner.Text())
+	bid, err := strconv.Atoi(lineFields[1].Text)
+	if err != nil {
+		errs.Add(lineFields[1].Errorf("invalid bid %q", lineFields[1].Text))
 	}
+	hand.Bid = bid
 
-	return ret
+	return hand, errs.Err()
 }
 
-func ParseHands(inputLines []string, isPartTwo bool) []Hand {
+// Parses and scores all hands. Blank lines are skipped. All parse errors
+// of the input are returned together.
+func ParseHands(inputLines []string, isPartTwo bool) ([]Hand, error) {
 	var ret []Hand
+	var errs input.ErrorList
+
 	for i := 0; i < len(inputLines); i++ {
-		hand := Hand{}
-		lineFields := strings.Fields(inputLines[i])
-		bid, err := strconv.Atoi(lineFields[1])
-		if err != nil {
-			log.Fatal("[ERROR] strconv err on bid:", err)
+		if len(strings.TrimSpace(inputLines[i])) == 0 {
+			continue
 		}
-		hand.Bid = bid
-		for j := 0; j < 5; j++ {
-			cardLabel := string(lineFields[0][j])
-			value, ok := g_cardValueMap[cardLabel]
-			if !ok {
-				log.Fatal("[ERROR] g_cardValueMap did not have value for label", cardLabel)
-			}
-			card := Card{Label: cardLabel, Value: value}
-			hand.Cards = append(hand.Cards, card)
+		hand, err := parseHand(inputLines[i])
+		if err != nil {
+			errs.AddLine(i+1, inputLines[i], err)
+			continue
 		}
 
 		if !isPartTwo {
@@ -216,7 +234,7 @@ func ParseHands(inputLines []string, isPartTwo bool) []Hand {
 		ret = append(ret, hand)
 	}
 
-	return ret
+	return ret, errs.Err()
 }
 
 func Solution1(hands []Hand) uint64 {
@@ -227,7 +245,7 @@ func Solution1(hands []Hand) uint64 {
 	for i := 0; i < len(hands); i++ {
 		rank := i + 1
 		ret += uint64(rank) * uint64(hands[i].Bid)
-		fmt.Println("Rank", i, hands[i])
+		logging.Trace(logger, "hand ranked", "rank", rank, "hand", hands[i])
 	}
 
 	return ret
@@ -237,13 +255,28 @@ func Solution2(hands []Hand) uint64 {
 	return Solution1(hands)
 }
 
-func main() {
-	inputLines := ReadInput("./input.txt")
-	hands := ParseHands(inputLines, false)
-	sol1 := Solution1(hands)
-	fmt.Println("Solution 1:", sol1)
+func Part1(r io.Reader) (string, error) {
+	inputLines, err := input.ReadLines(r)
+	if err != nil {
+		return "", err
+	}
+	hands, err := ParseHands(inputLines, false)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatUint(Solution1(hands), 10), nil
+}
+
+func Part2(r io.Reader) (string, error) {
+	inputLines, err := input.ReadLines(r)
+	if err != nil {
+		return "", err
+	}
+	hands, err := ParseHands(inputLines, true)
+	if err != nil {
+		return "", err
+	}
 
-	hands2 := ParseHands(inputLines, true)
-	sol2 := Solution2(hands2)
-	fmt.Println("Solution 2:", sol2)
+	return strconv.FormatUint(Solution2(hands), 10), nil
 }
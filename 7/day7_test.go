@@ -0,0 +1,28 @@
+package day7
+
+import (
+	"testing"
+
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHandsErrors(t *testing.T) {
+	lines := []string{
+		"32T3K 765",
+		"",
+		"T55X5 684",
+		"KK677 2o8",
+		"KTJJ 220",
+		"foo bar",
+	}
+	_, err := ParseHands(lines, false)
+	var errs input.ErrorList
+	assert.ErrorAs(t, err, &errs)
+	assert.Len(t, errs, 5)
+	assert.Equal(t, input.ParseError{Line: 3, Column: 4, Input: lines[2], Reason: `unknown card label "X"`}, *errs[0])
+	assert.Equal(t, input.ParseError{Line: 4, Column: 7, Input: lines[3], Reason: `invalid bid "2o8"`}, *errs[1])
+	assert.Equal(t, input.ParseError{Line: 5, Column: 1, Input: lines[4], Reason: `expected 5 cards, got 4`}, *errs[2])
+	assert.Equal(t, input.ParseError{Line: 6, Column: 1, Input: lines[5], Reason: `expected 5 cards, got 3`}, *errs[3])
+	assert.Equal(t, input.ParseError{Line: 6, Column: 5, Input: lines[5], Reason: `invalid bid "bar"`}, *errs[4])
+}
@@ -0,0 +1,322 @@
+package day8
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/prki/aoc2023/lib/logging"
+)
+
+var logger = logging.Day(8)
+
+type TargetNode struct {
+	LeftTarget  string
+	RightTarget string
+}
+
+type FSM struct {
+	Graph               map[string]TargetNode
+	CurrStates          []string
+	AcceptedInputsCount uint64
+	InitStates          []string
+	AcceptStates        []string
+	AcceptCounter       map[string]int
+	AcceptSteps         []uint64 // steps after which each init state first accepted
+}
+
+// Initializes FSM by assigning the state graph and setting
+// curr states to initial/input states.
+func NewFSM(graph map[string]TargetNode) *FSM {
+	var initStates []string
+	var acceptStates []string
+	for k := range graph {
+		if k[len(k)-1] == 'A' {
+			initStates = append(initStates, k)
+		}
+		if k[len(k)-1] == 'Z' {
+			acceptStates = append(acceptStates, k)
+		}
+	}
+
+	var currStatesCopy []string
+	currStatesCopy = append(currStatesCopy, initStates...)
+
+	fsm := &FSM{
+		Graph:               graph,
+		CurrStates:          currStatesCopy,
+		InitStates:          initStates,
+		AcceptStates:        acceptStates,
+		AcceptedInputsCount: 0,
+		AcceptCounter:       make(map[string]int),
+		AcceptSteps:         make([]uint64, len(initStates)),
+	}
+
+	return fsm
+}
+
+func (f *FSM) IsInAcceptState() bool {
+	inAcceptState := true
+
+	for i := 0; i < len(f.CurrStates); i++ {
+		currState := f.CurrStates[i]
+		if currState[len(currState)-1] == 'Z' {
+			logging.Trace(logger, "init state is in accept state", "initState", f.InitStates[i], "state", currState, "steps", f.AcceptedInputsCount)
+			f.AcceptCounter[currState] += 1
+			if f.AcceptSteps[i] == 0 {
+				f.AcceptSteps[i] = f.AcceptedInputsCount
+			}
+			if f.AcceptCounter[currState] == 1 {
+				logger.Info("init state discovered accept state", "initState", f.InitStates[i], "state", currState, "steps", f.AcceptedInputsCount)
+			} else if f.AcceptCounter[currState] == 2 {
+				logger.Info("init state discovered same accept state", "initState", f.InitStates[i], "state", currState, "steps", f.AcceptedInputsCount)
+			}
+		}
+		if currState[len(currState)-1] != 'Z' {
+			inAcceptState = false
+		}
+	}
+
+	return inAcceptState
+}
+
+// All of the current states accept input passed as a parameter
+// and move to the next state.
+func (f *FSM) AcceptInput(input byte) {
+	for i := 0; i < len(f.CurrStates); i++ {
+		if input == 'R' {
+			f.CurrStates[i] = f.Graph[f.CurrStates[i]].RightTarget
+		} else {
+			f.CurrStates[i] = f.Graph[f.CurrStates[i]].LeftTarget
+		}
+	}
+	f.AcceptedInputsCount += 1
+}
+
+// Parses a "AAA = (BBB, CCC)" graph line into the fields of the node and its
+// left and right target.
+func parseNodeLine(inputLine string) ([]input.Field, error) {
+	var errs input.ErrorList
+
+	line := input.NewLine(inputLine)
+	nodeSplit := line.Split("=")
+	if len(nodeSplit) != 2 {
+		errs.Add(line.Errorf("expected \"<node> = (<left>, <right>)\""))
+		return nil, errs.Err()
+	}
+	startNode := nodeSplit[0].TrimSpace()
+	targets := nodeSplit[1].TrimSpace()
+	if !strings.HasPrefix(targets.Text, "(") || !strings.HasSuffix(targets.Text, ")") {
+		errs.Add(targets.Errorf("expected targets in parentheses"))
+		return nil, errs.Err()
+	}
+	targetSplit := targets.Slice(1, len(targets.Text)-1).Split(",")
+	if len(targetSplit) != 2 {
+		errs.Add(targets.Errorf("expected exactly two targets"))
+		return nil, errs.Err()
+	}
+
+	nodes := []input.Field{startNode, targetSplit[0], targetSplit[1]}
+	for i := range nodes {
+		nodes[i] = nodes[i].TrimSpace()
+		if len(nodes[i].Text) == 0 || len(nodes[i].Fields()) != 1 {
+			errs.Add(nodes[i].Errorf("invalid node name %q", nodes[i].Text))
+		}
+	}
+
+	return nodes, errs.Err()
+}
+
+// Input consists of two blocks - a header with move directions and the graph
+// with one node per line. Every target must be a node of the graph. All parse
+// errors of the input are returned together.
+func ParseGraph(inputLines []string) (string, map[string]TargetNode, error) {
+	graph := make(map[string]TargetNode)
+	var errs input.ErrorList
+
+	blocks := input.Blocks(inputLines)
+	if len(blocks) != 2 {
+		errs.Add(&input.ParseError{Line: 1, Reason: fmt.Sprintf("expected directions and graph separated by a blank line, got %d blocks", len(blocks))})
+		return "", nil, errs.Err()
+	}
+
+	moveDirs := strings.Join(blocks[0].Lines, "")
+	for i, line := range blocks[0].Lines {
+		for j := 0; j < len(line); j++ {
+			if line[j] != 'L' && line[j] != 'R' {
+				errs.Add(&input.ParseError{Line: blocks[0].Line + i, Column: j + 1, Input: line, Reason: fmt.Sprintf("invalid direction %q", line[j])})
+			}
+		}
+	}
+
+	nodeLines := make(map[int][]input.Field) // line -> node and target fields
+	for i, line := range blocks[1].Lines {
+		nodes, err := parseNodeLine(line)
+		if err != nil {
+			errs.AddLine(blocks[1].Line+i, line, err)
+			continue
+		}
+		if _, ok := graph[nodes[0].Text]; ok {
+			errs.AddLine(blocks[1].Line+i, line, nodes[0].Errorf("node %q defined more than once", nodes[0].Text))
+			continue
+		}
+		graph[nodes[0].Text] = TargetNode{LeftTarget: nodes[1].Text, RightTarget: nodes[2].Text}
+		nodeLines[blocks[1].Line+i] = nodes
+	}
+	for i, line := range blocks[1].Lines {
+		nodes, ok := nodeLines[blocks[1].Line+i]
+		if !ok {
+			continue
+		}
+		for _, target := range nodes[1:] {
+			if _, ok := graph[target.Text]; !ok && len(target.Text) > 0 {
+				errs.AddLine(blocks[1].Line+i, line, target.Errorf("undefined node %q", target.Text))
+			}
+		}
+	}
+	errs.Sort()
+
+	return moveDirs, graph, errs.Err()
+}
+
+func FollowDirs(moveDirs string, graph map[string]TargetNode, startNode *string) int {
+	if *startNode == "ZZZ" {
+		return 0
+	}
+
+	stepCounter := 0
+	currNode := *startNode
+	for i := 0; i < len(moveDirs); i++ {
+		stepCounter += 1
+		if moveDirs[i] == 'R' {
+			currNode = graph[currNode].RightTarget
+		} else {
+			currNode = graph[currNode].LeftTarget
+		}
+
+		if currNode == "ZZZ" {
+			break
+		}
+	}
+
+	*startNode = currNode
+
+	return stepCounter
+}
+
+// "Naive"? approach which simply follows directions until target is found.
+// Fails if AAA or ZZZ is missing or ZZZ can't be reached from AAA.
+func Solution1(moveDirs string, graph map[string]TargetNode) (int, error) {
+	for _, node := range []string{"AAA", "ZZZ"} {
+		if _, ok := graph[node]; !ok {
+			return 0, &input.ParseError{Reason: fmt.Sprintf("no node %s in the graph", node)}
+		}
+	}
+
+	totalSteps := 0
+	lastNode := "AAA"
+	passStarts := make(map[string]bool) // nodes on which a pass of directions started
+	for {
+		if passStarts[lastNode] {
+			return 0, errors.New("ZZZ can't be reached from AAA")
+		}
+		passStarts[lastNode] = true
+		steps := FollowDirs(moveDirs, graph, &lastNode)
+		totalSteps += steps
+		if lastNode == "ZZZ" {
+			break
+		}
+	}
+
+	return totalSteps, nil
+}
+
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
+
+func lcm(a, b uint64) uint64 {
+	return a / gcd(a, b) * b
+}
+
+// Through analyzing the graph, it became clear the graph is actually
+// consisting of count(init_states) subgraphs. These graphs are then
+// looping in a period.
+// Therefore, assuming that e.g. one subgraph has a period of 2 and another
+// of 5, we are in accepting states after 10 steps. This is equivalent to
+// finding LCM.
+// That being said, in this case, surprisingly enough the period itself
+// turned out to be the same as distance from the start, so the answer is
+// the LCM of the distances of all init states to their first accept state.
+func Solution2(moveDirs string, graph map[string]TargetNode) uint64 {
+	totalSteps := uint64(0)
+	fsm := NewFSM(graph)
+	moveDirIdx := 0
+	for {
+		totalSteps += 1
+		if moveDirIdx >= len(moveDirs) {
+			moveDirIdx = 0
+		}
+		fsm.AcceptInput(moveDirs[moveDirIdx])
+		isAccepted := fsm.IsInAcceptState()
+		foundCounter := 0
+		for _, v := range fsm.AcceptCounter {
+			if v >= 2 {
+				foundCounter += 1
+			}
+		}
+		if foundCounter == len(fsm.InitStates) {
+			logger.Info("all init states with periods of movement found", "steps", totalSteps, "distances", fsm.AcceptSteps)
+			ret := uint64(1)
+			for _, steps := range fsm.AcceptSteps {
+				ret = lcm(ret, steps)
+			}
+
+			return ret
+		}
+		if isAccepted {
+			break
+		}
+		moveDirIdx++
+	}
+
+	return totalSteps
+}
+
+func Part1(r io.Reader) (string, error) {
+	inputLines, err := input.ReadLines(r)
+	if err != nil {
+		return "", err
+	}
+	moveDirs, graph, err := ParseGraph(inputLines)
+	if err != nil {
+		return "", err
+	}
+
+	solution, err := Solution1(moveDirs, graph)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(solution), nil
+}
+
+func Part2(r io.Reader) (string, error) {
+	inputLines, err := input.ReadLines(r)
+	if err != nil {
+		return "", err
+	}
+	moveDirs, graph, err := ParseGraph(inputLines)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatUint(Solution2(moveDirs, graph), 10), nil
+}
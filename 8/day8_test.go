@@ -0,0 +1,62 @@
+package day8
+
+import (
+	"testing"
+
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGraphErrors(t *testing.T) {
+	lines := []string{
+		"LRxL",
+		"",
+		"AAA = (BBB, CCC)",
+		"BBB = BBB, BBB",
+		"CCC = (ZZZ)",
+		"DDD = ( , ZZZ)",
+	}
+	_, _, err := ParseGraph(lines)
+	var errs input.ErrorList
+	assert.ErrorAs(t, err, &errs)
+	assert.Len(t, errs, 6)
+	assert.Equal(t, input.ParseError{Line: 1, Column: 3, Input: lines[0], Reason: `invalid direction 'x'`}, *errs[0])
+	assert.Equal(t, input.ParseError{Line: 3, Column: 8, Input: lines[2], Reason: `undefined node "BBB"`}, *errs[1])
+	assert.Equal(t, input.ParseError{Line: 3, Column: 13, Input: lines[2], Reason: `undefined node "CCC"`}, *errs[2])
+	assert.Equal(t, input.ParseError{Line: 4, Column: 7, Input: lines[3], Reason: `expected targets in parentheses`}, *errs[3])
+	assert.Equal(t, input.ParseError{Line: 5, Column: 7, Input: lines[4], Reason: `expected exactly two targets`}, *errs[4])
+	assert.Equal(t, input.ParseError{Line: 6, Column: 9, Input: lines[5], Reason: `invalid node name ""`}, *errs[5])
+}
+
+func TestSolution1Unreachable(t *testing.T) {
+	_, graph, err := ParseGraph([]string{"LR", "", "BBB = (BBB, BBB)"})
+	assert.NoError(t, err)
+	_, err = Solution1("LR", graph)
+	assert.EqualError(t, err, "no node AAA in the graph")
+
+	_, graph, err = ParseGraph([]string{"LR", "", "AAA = (AAA, AAA)", "ZZZ = (ZZZ, ZZZ)"})
+	assert.NoError(t, err)
+	_, err = Solution1("LR", graph)
+	assert.EqualError(t, err, "ZZZ can't be reached from AAA")
+
+	_, _, err = ParseGraph([]string{"LR", "", "AAA = (ZZZ, AAA)", "ZZZ = (ZZZ, ZZZ)", "AAA = (AAA, AAA)"})
+	assert.EqualError(t, err, `line 5, column 1: node "AAA" defined more than once in "AAA = (AAA, AAA)"`)
+}
+
+func TestSolution2(t *testing.T) {
+	lines := []string{
+		"LR",
+		"",
+		"11A = (11B, XXX)",
+		"11B = (XXX, 11Z)",
+		"11Z = (11B, XXX)",
+		"22A = (22B, XXX)",
+		"22B = (22C, 22C)",
+		"22C = (22Z, 22Z)",
+		"22Z = (22B, 22B)",
+		"XXX = (XXX, XXX)",
+	}
+	moveDirs, graph, err := ParseGraph(lines)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), Solution2(moveDirs, graph))
+}
@@ -1,14 +1,15 @@
-package main
+package day9
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
+	"io"
 	"strconv"
-	"strings"
+
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/prki/aoc2023/lib/logging"
 )
 
+var logger = logging.Day(9)
+
 // Struct "abstracting" the calculation tree.
 // Contains slices/arrays of all the diff steps together with a New method
 // which executes the calculation.
@@ -88,37 +89,31 @@ func (h *HistoryDiffs) ExtrapolateValuePast() int64 {
 	return ret
 }
 
-func ReadInput(path string) []string {
-	ret := make([]string, 0)
-	fil, err := os.Open(path)
-	if err != nil {
-		log.Fatalf("[ERROR] Can't open file %s\n", path)
-	}
-	defer fil.Close()
-
-	scanner := bufio.NewScanner(fil)
-	for scanner.Scan() {
-		ret = append(ret, scanner.Text())
-	}
-
-	return ret
-}
-
-func ParseHistories(input []string) [][]int64 {
-	ret := make([][]int64, len(input))
-	for i := 0; i < len(input); i++ {
-		line := input[i]
-		nums := strings.Fields(line)
+// Parses one history per line. Blank lines are skipped. All parse errors
+// of the input are returned together.
+func ParseHistories(inputLines []string) ([][]int64, error) {
+	var ret [][]int64
+	var errs input.ErrorList
+
+	for i := 0; i < len(inputLines); i++ {
+		line := input.NewLine(inputLines[i])
+		nums := line.Fields()
+		if len(nums) == 0 {
+			continue
+		}
+		var history []int64
 		for j := 0; j < len(nums); j++ {
-			currNum, err := strconv.ParseInt(nums[j], 10, 64)
+			currNum, err := strconv.ParseInt(nums[j].Text, 10, 64)
 			if err != nil {
-				log.Fatal("[ERROR] err parsing histories:", err)
+				errs.AddLine(i+1, line.Text, nums[j].Errorf("invalid history value %q", nums[j].Text))
+				continue
 			}
-			ret[i] = append(ret[i], currNum)
+			history = append(history, currNum)
 		}
+		ret = append(ret, history)
 	}
 
-	return ret
+	return ret, errs.Err()
 }
 
 func Solution1(hists [][]int64) int64 {
@@ -127,14 +122,13 @@ func Solution1(hists [][]int64) int64 {
 	for i := 0; i < len(hists); i++ {
 		histDiffs := NewHistoryDiffs(hists[i])
 		histDiffs.CalculateHistoryDiffs()
-		/*fmt.Println("[DBG] History calculation for", hists[i])
+		logger.Debug("history calculated", "history", hists[i], "steps", len(histDiffs.Histories))
 		for j := 0; j < len(histDiffs.Histories); j++ {
-			fmt.Println("Hist step", j, histDiffs.Histories[j])
+			logging.Trace(logger, "history step", "history", i, "step", j, "diffs", histDiffs.Histories[j])
 		}
-		*/
 
 		extrapolated := histDiffs.ExtrapolateValue()
-		//fmt.Println("Extrapolated value for hist", i, "-", extrapolated)
+		logger.Debug("extrapolated value", "history", i, "value", extrapolated)
 		ret += extrapolated
 	}
 
@@ -155,12 +149,28 @@ func Solution2(hists [][]int64) int64 {
 	return ret
 }
 
-func main() {
-	input := ReadInput("input.txt")
-	histories := ParseHistories(input)
-	sol1 := Solution1(histories)
-	fmt.Println("Solution 1:", sol1)
+func Part1(r io.Reader) (string, error) {
+	lines, err := input.ReadLines(r)
+	if err != nil {
+		return "", err
+	}
+	histories, err := ParseHistories(lines)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.FormatInt(Solution1(histories), 10), nil
+}
+
+func Part2(r io.Reader) (string, error) {
+	lines, err := input.ReadLines(r)
+	if err != nil {
+		return "", err
+	}
+	histories, err := ParseHistories(lines)
+	if err != nil {
+		return "", err
+	}
 
-	sol2 := Solution2(histories)
-	fmt.Println("Solution 2:", sol2)
+	return strconv.FormatInt(Solution2(histories), 10), nil
 }
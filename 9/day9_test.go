@@ -0,0 +1,22 @@
+package day9
+
+import (
+	"testing"
+
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHistoriesErrors(t *testing.T) {
+	lines := []string{
+		"0 3 6 9 12 15",
+		"",
+		"1 3 six 10 15 2.1",
+	}
+	_, err := ParseHistories(lines)
+	var errs input.ErrorList
+	assert.ErrorAs(t, err, &errs)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, input.ParseError{Line: 3, Column: 5, Input: lines[2], Reason: `invalid history value "six"`}, *errs[0])
+	assert.Equal(t, input.ParseError{Line: 3, Column: 15, Input: lines[2], Reason: `invalid history value "2.1"`}, *errs[1])
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"github.com/prki/aoc2023/lib/logging"
+)
+
+// Benchmark result of a single task.
+type BenchResult struct {
+	Day         int    `json:"day"`
+	Part        int    `json:"part"`
+	Input       string `json:"input"`
+	Iterations  int    `json:"iterations"`
+	NsPerOp     int64  `json:"ns_per_op"`
+	AllocsPerOp int64  `json:"allocs_per_op"`
+	BytesPerOp  int64  `json:"bytes_per_op"`
+}
+
+// Benchmarks a task on the given input. The task is solved once before
+// benchmarking, so that broken inputs are reported instead of measured.
+func BenchmarkTask(task Task, puzzleInput []byte) (testing.BenchmarkResult, error) {
+	if _, err := task.Solve(puzzleInput); err != nil {
+		return testing.BenchmarkResult{}, err
+	}
+
+	ret := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			task.Solve(puzzleInput)
+		}
+	})
+
+	return ret, nil
+}
+
+func printBenchTable(w io.Writer, results []BenchResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "DAY\tPART\tINPUT\tN\tTIME/OP\tALLOCS/OP\tB/OP\t")
+	for _, res := range results {
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%d\t%v\t%d\t%d\t\n",
+			res.Day, res.Part, res.Input, res.Iterations, time.Duration(res.NsPerOp), res.AllocsPerOp, res.BytesPerOp)
+	}
+
+	return tw.Flush()
+}
+
+func benchCommand(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	dayNumber := fs.Int("day", 0, "day to benchmark, 0 benchmarks all days")
+	part := fs.Int("part", 0, "part to benchmark, 0 benchmarks all parts")
+	inputPath := fs.String("input", "", "puzzle input (default <dir>/<day>/input.txt)")
+	dir := fs.String("dir", ".", "repository root containing the day directories")
+	benchTime := fs.String("benchtime", "1s", "run each benchmark for duration d or Nx iterations, see go help testflag")
+	jsonOutput := fs.Bool("json", false, "print results as JSON instead of a table")
+	fs.Parse(args)
+
+	if *inputPath != "" && *dayNumber == 0 {
+		return errors.New("-input can only be used together with -day")
+	}
+
+	// testing.Benchmark takes the benchmark duration from the test flags
+	testing.Init()
+	if err := flag.Set("test.benchtime", *benchTime); err != nil {
+		return fmt.Errorf("invalid -benchtime: %w", err)
+	}
+
+	tasks, err := SelectTasks(*dayNumber, *part)
+	if err != nil {
+		return err
+	}
+
+	// logging would distort the measurements
+	logging.Disable()
+
+	var results []BenchResult
+	inputs := NewInputCache(*dir, *inputPath)
+	for _, task := range tasks {
+		puzzleInput, err := inputs.Get(task.Day.Number)
+		if err != nil {
+			return err
+		}
+
+		res, err := BenchmarkTask(task, puzzleInput)
+		if err != nil {
+			return err
+		}
+		results = append(results, BenchResult{
+			Day:         task.Day.Number,
+			Part:        task.Part,
+			Input:       inputs.InputPath(task.Day.Number),
+			Iterations:  res.N,
+			NsPerOp:     res.NsPerOp(),
+			AllocsPerOp: res.AllocsPerOp(),
+			BytesPerOp:  res.AllocedBytesPerOp(),
+		})
+	}
+
+	if *jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+
+	return printBenchTable(os.Stdout, results)
+}
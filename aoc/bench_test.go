@@ -0,0 +1,40 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/prki/aoc2023/lib/logging"
+)
+
+// Benchmarks every registered part on the default input of its day, e.g.
+//
+//	go test -run '^$' -bench 'Parts/day7' ./aoc
+func BenchmarkParts(b *testing.B) {
+	tasks, err := SelectTasks(0, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	logging.Disable()
+	defer logging.Configure(os.Stderr, logging.Levels{Default: slog.LevelInfo})
+
+	inputs := NewInputCache("..", "")
+	for _, task := range tasks {
+		puzzleInput, err := inputs.Get(task.Day.Number)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		task := task
+		b.Run(task.Name(), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := task.Solve(puzzleInput); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	day1 "github.com/prki/aoc2023/1"
+	"github.com/prki/aoc2023/lib/input"
+)
+
+func day1Command(args []string) error {
+	fs := flag.NewFlagSet("day1", flag.ExitOnError)
+	inputPath := fs.String("input", "", "puzzle input, \"-\" reads stdin (default <dir>/1/input.txt)")
+	dir := fs.String("dir", ".", "repository root containing the day directories")
+	vocab := fs.String("vocab", "en", "comma separated vocabularies of spelled out digits, built-in are "+strings.Join(day1.VocabularyNames(), ", "))
+	vocabFile := fs.String("vocab-file", "", "TOML file with the vocabulary configuration, -vocab and -ignore-case override it if given")
+	ignoreCase := fs.Bool("ignore-case", false, "match spelled out digits regardless of case")
+	explain := fs.Bool("explain", false, "explain the calibration value of every line")
+	workers := fs.Int("workers", 0, "number of goroutines scanning lines, 0 scans sequentially")
+	progressEvery := fs.Int("progress", 0, "print running totals to stderr every n lines, 0 disables")
+	highlight := fs.String("highlight", "auto", "marking of digits chosen by -explain, ansi, brackets or auto (ansi on terminals)")
+	setupLogging := addLoggingFlags(fs)
+	fs.Parse(args)
+
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	var h day1.Highlight
+	switch *highlight {
+	case "ansi":
+		h = day1.HighlightANSI
+	case "brackets":
+		h = day1.HighlightBrackets
+	case "auto":
+		h = day1.HighlightBrackets
+		if isTerminal(os.Stdout) {
+			h = day1.HighlightANSI
+		}
+	default:
+		return fmt.Errorf("unknown highlight %q", *highlight)
+	}
+
+	config := day1.VocabularyConfig{Vocabularies: day1.ParseVocabularyNames(*vocab)}
+	if *vocabFile != "" {
+		var err error
+		config, err = day1.LoadVocabularyConfig(*vocabFile)
+		if err != nil {
+			return err
+		}
+	}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "vocab":
+			config.Vocabularies = day1.ParseVocabularyNames(*vocab)
+		case "ignore-case":
+			config.IgnoreCase = *ignoreCase
+		}
+	})
+	scanner, err := config.Scanner()
+	if err != nil {
+		return err
+	}
+
+	if *inputPath == "" {
+		*inputPath = DefaultInputPath(*dir, 1)
+	}
+	fil, err := input.Open(*inputPath)
+	if err != nil {
+		return err
+	}
+	defer fil.Close()
+
+	if *explain {
+		_, err = day1.Explain(fil, os.Stdout, scanner, h)
+		if err != nil {
+			return fmt.Errorf("%s: %w", *inputPath, err)
+		}
+		return nil
+	}
+
+	opts := day1.StreamOptions{
+		Workers:       *workers,
+		ProgressEvery: *progressEvery,
+		Progress: func(totals day1.Totals) {
+			fmt.Fprintf(os.Stderr, "%d lines, sum %d, %d lines without digits\n", totals.Lines, totals.Sum, totals.NoDigits)
+		},
+	}
+	totals, err := day1.CalibrateStream(fil, scanner, opts)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *inputPath, err)
+	}
+	fmt.Fprintf(os.Stdout, "Day 1 calibration: %d\n", totals.Sum)
+
+	return nil
+}
+
+// Returns whether f is a terminal rather than a file or a pipe.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
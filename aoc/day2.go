@@ -0,0 +1,142 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	day2 "github.com/prki/aoc2023/2"
+	"github.com/prki/aoc2023/lib/input"
+)
+
+// Candidate bags given by repeated -bag flags.
+type bagsFlag []day2.Bag
+
+func (b *bagsFlag) String() string {
+	return fmt.Sprint(*b)
+}
+
+func (b *bagsFlag) Set(spec string) error {
+	cfg, err := day2.ParseBag(spec)
+	if err != nil {
+		return err
+	}
+	*b = append(*b, day2.Bag{Name: cfg.String(), Cubes: cfg})
+
+	return nil
+}
+
+func day2Command(args []string) error {
+	if len(args) > 0 && args[0] == "infer" {
+		return day2InferCommand(args[1:])
+	}
+
+	var bags bagsFlag
+
+	fs := flag.NewFlagSet("day2", flag.ExitOnError)
+	inputPath := fs.String("input", "", "puzzle input, \"-\" reads stdin (default <dir>/2/input.txt)")
+	dir := fs.String("dir", ".", "repository root containing the day directories")
+	fs.Var(&bags, "bag", "candidate bag, e.g. red=12,green=13,blue=14, may be repeated (default is the puzzle bag)")
+	bagsFile := fs.String("bags", "", "JSON or YAML file with a list of candidate bags")
+	impossible := fs.Bool("impossible", false, "list games impossible with each bag and the reasons")
+	setupLogging := addLoggingFlags(fs)
+	fs.Parse(args)
+
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	if *bagsFile != "" {
+		fileBags, err := day2.LoadBags(*bagsFile)
+		if err != nil {
+			return err
+		}
+		bags = append(bags, fileBags...)
+	}
+	if len(bags) == 0 {
+		bags = append(bags, day2.Bag{Name: day2.PuzzleBag.String(), Cubes: day2.PuzzleBag})
+	}
+
+	games, err := readDay2Games(*dir, *inputPath)
+	if err != nil {
+		return err
+	}
+
+	for _, bag := range bags {
+		fmt.Fprintf(os.Stdout, "Day 2 part 1 (%s): %d\n", bag.Name, day2.SumPossibleIds(games, bag.Cubes))
+		if !*impossible {
+			continue
+		}
+		reports := day2.ImpossibleGames(games, bag.Cubes)
+		fmt.Fprintf(os.Stdout, "%d of %d games impossible:\n", len(reports), len(games))
+		for _, report := range reports {
+			fmt.Fprintf(os.Stdout, "  %s\n", report)
+		}
+	}
+	fmt.Fprintf(os.Stdout, "Day 2 part 2: %d\n", day2.SumPowers(games))
+
+	return nil
+}
+
+// Reads games on path, or the default input of day 2 if path is empty.
+func readDay2Games(dir, path string) ([]day2.CubeGame, error) {
+	if path == "" {
+		path = DefaultInputPath(dir, 2)
+	}
+	fil, err := input.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fil.Close()
+
+	games, err := day2.ReadGames(fil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return games, nil
+}
+
+func day2InferCommand(args []string) error {
+	fs := flag.NewFlagSet("day2 infer", flag.ExitOnError)
+	inputPath := fs.String("input", "", "puzzle input, \"-\" reads stdin (default <dir>/2/input.txt)")
+	dir := fs.String("dir", ".", "repository root containing the day directories")
+	maxCubes := fs.Int("max", day2.DefaultInferenceOptions.MaxCubes, "most cubes of a single color considered")
+	confidence := fs.Float64("confidence", day2.DefaultInferenceOptions.Confidence, "confidence level of the intervals")
+	setupLogging := addLoggingFlags(fs)
+	fs.Parse(args)
+
+	if err := setupLogging(); err != nil {
+		return err
+	}
+
+	games, err := readDay2Games(*dir, *inputPath)
+	if err != nil {
+		return err
+	}
+	estimate, err := day2.InferBag(games, day2.InferenceOptions{MaxCubes: *maxCubes, Confidence: *confidence})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stdout, "Bag estimate from %d rounds of %d games, %g%% confidence intervals:\n", estimate.Rounds, len(games), *confidence*100)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "COLOR\tOBSERVED\tESTIMATE\tINTERVAL")
+	unbounded := false
+	for _, c := range estimate.Colors {
+		interval := fmt.Sprintf("%d-%d", c.Low, c.High)
+		if !c.Bounded {
+			interval += "+"
+			unbounded = true
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\n", c.Color, c.Observed, c.Estimate, interval)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if unbounded {
+		fmt.Fprintf(os.Stdout, "Intervals marked + reach -max %d: the rounds don't bound the bag size, e.g. because they weren't drawn from a single bag.\n", *maxCubes)
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	day3 "github.com/prki/aoc2023/3"
+	"github.com/prki/aoc2023/lib/input"
+)
+
+// Gear rules given by repeated -gear flags.
+type gearRulesFlag []day3.GearRule
+
+func (g *gearRulesFlag) String() string {
+	return fmt.Sprint(*g)
+}
+
+func (g *gearRulesFlag) Set(spec string) error {
+	rule, err := day3.ParseGearRule(spec)
+	if err != nil {
+		return err
+	}
+	*g = append(*g, rule)
+
+	return nil
+}
+
+func day3Command(args []string) error {
+	if len(args) > 0 && args[0] == "graph" {
+		return day3GraphCommand(args[1:])
+	}
+	if len(args) > 0 && args[0] == "render" {
+		return day3RenderCommand(args[1:])
+	}
+
+	var rules gearRulesFlag
+
+	fs := flag.NewFlagSet("day3", flag.ExitOnError)
+	inputPath := fs.String("input", "", "puzzle input, \"-\" reads stdin (default <dir>/3/input.txt)")
+	dir := fs.String("dir", ".", "repository root containing the day directories")
+	fs.Var(&rules, "gear", "gear rule, e.g. symbols=*#,min=2,aggregate=sum, may be repeated (default is the puzzle rule)")
+	listGears := fs.Bool("gears", false, "list gears found by each rule")
+	stream := fs.Bool("stream", false, "solve row by row keeping only three rows in memory, e.g. for endless inputs on stdin")
+	listParts := fs.Bool("parts", false, "list part numbers as they are found, requires -stream")
+	setupLogging := addLoggingFlags(fs)
+	fs.Parse(args)
+
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		rules = append(rules, day3.PuzzleGearRule)
+	}
+	if *stream {
+		return day3Stream(*dir, *inputPath, rules, *listParts, *listGears)
+	}
+	if *listParts {
+		return fmt.Errorf("-parts requires -stream")
+	}
+
+	diagram, err := readDay3Diagram(*dir, *inputPath)
+	if err != nil {
+		return err
+	}
+	partSum, index := day3.IndexDiagram(diagram)
+
+	fmt.Fprintf(os.Stdout, "Day 3 part 1: %d\n", partSum)
+	for _, rule := range rules {
+		fmt.Fprintf(os.Stdout, "Day 3 part 2 (%s): %d\n", rule, index.Sum(rule))
+		if !*listGears {
+			continue
+		}
+		for _, gear := range index.Gears(rule) {
+			fmt.Fprintf(os.Stdout, "  %c at %d,%d: %v = %d\n", gear.Symbol.Symbol, gear.Coords.X, gear.Coords.Y, gear.Numbers, gear.Value)
+		}
+	}
+
+	return nil
+}
+
+// Solves the schematic on path by SolveStream, listing part numbers and gears
+// as they are found if requested.
+func day3Stream(dir, path string, rules []day3.GearRule, listParts, listGears bool) error {
+	if path == "" {
+		path = DefaultInputPath(dir, 3)
+	}
+	fil, err := input.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fil.Close()
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	opts := day3.StreamOptions{Rules: rules}
+	if listParts {
+		opts.OnPart = func(part day3.PartNumber) {
+			fmt.Fprintf(out, "part %d at %d,%d\n", part.Value, part.Start.X, part.Start.Y)
+		}
+	}
+	if listGears {
+		opts.OnGear = func(rule int, gear day3.Gear) {
+			fmt.Fprintf(out, "gear %c at %d,%d (%s): %v = %d\n", gear.Symbol.Symbol, gear.Coords.X, gear.Coords.Y, rules[rule], gear.Numbers, gear.Value)
+		}
+	}
+	totals, err := day3.SolveStream(fil, opts)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	fmt.Fprintf(out, "Day 3 part 1: %d\n", totals.PartSum)
+	for i, rule := range rules {
+		fmt.Fprintf(out, "Day 3 part 2 (%s): %d\n", rule, totals.GearSums[i])
+	}
+
+	return out.Flush()
+}
+
+func day3GraphCommand(args []string) error {
+	fs := flag.NewFlagSet("day3 graph", flag.ExitOnError)
+	inputPath := fs.String("input", "", "puzzle input, \"-\" reads stdin (default <dir>/3/input.txt)")
+	dir := fs.String("dir", ".", "repository root containing the day directories")
+	format := fs.String("format", "dot", "output format: dot, json or components")
+	setupLogging := addLoggingFlags(fs)
+	fs.Parse(args)
+
+	if err := setupLogging(); err != nil {
+		return err
+	}
+
+	diagram, err := readDay3Diagram(*dir, *inputPath)
+	if err != nil {
+		return err
+	}
+	graph := day3.BuildGraph(day3.LoadDiagram(diagram))
+
+	switch *format {
+	case "dot":
+		return graph.WriteDOT(os.Stdout)
+	case "json":
+		return graph.WriteJSON(os.Stdout)
+	case "components":
+		for _, component := range graph.Components() {
+			sum := 0
+			for _, n := range component {
+				sum += n.Value
+			}
+			fmt.Fprintf(os.Stdout, "%d numbers, sum %d:", len(component), sum)
+			for _, n := range component {
+				fmt.Fprintf(os.Stdout, " %s@%d,%d", n.Text, n.Start.X, n.Start.Y)
+			}
+			fmt.Fprintln(os.Stdout)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown graph format %q, expected dot, json or components", *format)
+}
+
+func day3RenderCommand(args []string) error {
+	fs := flag.NewFlagSet("day3 render", flag.ExitOnError)
+	inputPath := fs.String("input", "", "puzzle input, \"-\" reads stdin (default <dir>/3/input.txt)")
+	dir := fs.String("dir", ".", "repository root containing the day directories")
+	gear := fs.String("gear", day3.PuzzleGearRule.String(), "rule of gears to highlight, e.g. symbols=*#,min=2")
+	format := fs.String("format", "", "output format: ansi or html (default html with -o, ansi otherwise)")
+	outPath := fs.String("o", "", "output file (default stdout)")
+	legend := fs.Bool("legend", false, "append a legend of the colors")
+	setupLogging := addLoggingFlags(fs)
+	fs.Parse(args)
+
+	if err := setupLogging(); err != nil {
+		return err
+	}
+
+	rule, err := day3.ParseGearRule(*gear)
+	if err != nil {
+		return err
+	}
+	if *format == "" {
+		*format = "ansi"
+		if *outPath != "" {
+			*format = "html"
+		}
+	}
+	if *format != "ansi" && *format != "html" {
+		return fmt.Errorf("unknown render format %q, expected ansi or html", *format)
+	}
+
+	diagram, err := readDay3Diagram(*dir, *inputPath)
+	if err != nil {
+		return err
+	}
+	annotated := day3.Annotate(day3.LoadDiagram(diagram), rule)
+
+	out := os.Stdout
+	if *outPath != "" {
+		out, err = os.Create(*outPath)
+		if err != nil {
+			return err
+		}
+	}
+	if *format == "html" {
+		err = annotated.WriteHTML(out, *legend)
+	} else {
+		err = annotated.WriteANSI(out, *legend)
+	}
+	if out != os.Stdout {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
+// Reads the schematic on path, or the default input of day 3 if path is empty.
+func readDay3Diagram(dir, path string) ([]string, error) {
+	if path == "" {
+		path = DefaultInputPath(dir, 3)
+	}
+	fil, err := input.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fil.Close()
+
+	diagram, err := input.ReadLines(fil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return diagram, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	day4 "github.com/prki/aoc2023/4"
+	"github.com/prki/aoc2023/lib/input"
+)
+
+func day4Command(args []string) error {
+	fs := flag.NewFlagSet("day4", flag.ExitOnError)
+	inputPath := fs.String("input", "", "puzzle input, \"-\" reads stdin (default <dir>/4/input.txt)")
+	dir := fs.String("dir", ".", "repository root containing the day directories")
+	explain := fs.Bool("explain", false, "break down instances of each card by the cards which won them")
+	strict := fs.Bool("strict", false, "reject inputs with repeated or invalid numbers, card ids out of order or missing, or differing number counts")
+	setupLogging := addLoggingFlags(fs)
+	fs.Parse(args)
+
+	if err := setupLogging(); err != nil {
+		return err
+	}
+	if *inputPath == "" {
+		*inputPath = DefaultInputPath(*dir, 4)
+	}
+	fil, err := input.Open(*inputPath)
+	if err != nil {
+		return err
+	}
+	defer fil.Close()
+	lines, err := input.ReadLines(fil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *inputPath, err)
+	}
+	parse := day4.ParseScratchcardGames
+	if *strict {
+		parse = day4.ParseScratchcardGamesStrict
+	}
+	games, err := parse(lines)
+	if err != nil {
+		return fmt.Errorf("%s: %w", *inputPath, err)
+	}
+
+	points := 0
+	for _, game := range games {
+		points += game.PointsAwarded
+	}
+	counts := day4.CountCards(games)
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	fmt.Fprintf(out, "Day 4 part 1: %d\n", points)
+	fmt.Fprintf(out, "Day 4 part 2: %s\n", counts.Total)
+	if *explain {
+		if err := counts.Explain(out); err != nil {
+			return err
+		}
+	}
+
+	return out.Flush()
+}
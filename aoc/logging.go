@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/prki/aoc2023/lib/logging"
+)
+
+// Configures logging of the days to stderr. Days log warnings and errors
+// only, unless verbose is set or spec says otherwise, see logging.ParseLevels.
+func configureLogging(spec string, verbose bool) error {
+	base := logging.Levels{Default: slog.LevelWarn}
+	if verbose {
+		base.Default = slog.LevelInfo
+	}
+
+	levels, err := logging.ParseLevels(spec, base)
+	if err != nil {
+		return err
+	}
+	logging.Configure(os.Stderr, levels)
+
+	return nil
+}
+
+// Adds the -log and -v flags to fs. The returned function configures logging
+// by their values, it is to be called once fs is parsed.
+func addLoggingFlags(fs *flag.FlagSet) func() error {
+	spec := fs.String("log", os.Getenv(logging.EnvVar), "log levels of the days, e.g. \"warn,5=trace\" (default $"+logging.EnvVar+")")
+	verbose := fs.Bool("v", false, "log at info level instead of warn by default")
+
+	return func() error {
+		return configureLogging(*spec, *verbose)
+	}
+}
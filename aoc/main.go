@@ -0,0 +1,66 @@
+// Command aoc runs the puzzle solutions of all days, e.g.:
+//
+//	aoc run -day 5 -part 2 -input 5/input_mini.txt
+//	aoc verify -day 5
+//	aoc bench -day 7 -json
+//	aoc day1 -vocab en,de -ignore-case
+//
+// Without -day, all days are run. Without -part, all parts of the selected
+// days are run. Inputs default to input.txt in the directory of each day.
+//
+// Some days have their own command, "aoc day<N>", with options which don't
+// fit the common parts, e.g. digit vocabularies of day 1.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: aoc <command> [flags]")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "Commands:")
+	fmt.Fprintln(os.Stderr, "  run    run solutions of selected days and parts")
+	fmt.Fprintln(os.Stderr, "  verify check solutions against known answers in answers.toml")
+	fmt.Fprintln(os.Stderr, "  bench  benchmark solutions of selected days and parts")
+	fmt.Fprintln(os.Stderr, "  day1   calibrate day 1 inputs with configurable vocabularies")
+	fmt.Fprintln(os.Stderr, "  day2   solve day 2 with candidate bags, \"day2 infer\" estimates the bag")
+	fmt.Fprintln(os.Stderr, "  day3   solve day 3 with configurable gear rules, \"day3 graph\" and \"day3 render\" export the schematic")
+	fmt.Fprintln(os.Stderr, "  day4   solve day 4, optionally explaining where card copies come from")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "Run 'aoc <command> -h' for flags of a command.")
+}
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCommand(os.Args[2:])
+	case "verify":
+		err = verifyCommand(os.Args[2:])
+	case "bench":
+		err = benchCommand(os.Args[2:])
+	case "help", "-h", "-help", "--help":
+		usage()
+	default:
+		day, ok := FindDayCommand(os.Args[1])
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", os.Args[1])
+			usage()
+			os.Exit(2)
+		}
+		err = day.Command(os.Args[2:])
+	}
+
+	if err != nil {
+		log.Fatal("[ERROR] ", err)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	day1 "github.com/prki/aoc2023/1"
+	day10 "github.com/prki/aoc2023/10"
+	day2 "github.com/prki/aoc2023/2"
+	day3 "github.com/prki/aoc2023/3"
+	day4 "github.com/prki/aoc2023/4"
+	day5 "github.com/prki/aoc2023/5"
+	day6 "github.com/prki/aoc2023/6"
+	day7 "github.com/prki/aoc2023/7"
+	day8 "github.com/prki/aoc2023/8"
+	day9 "github.com/prki/aoc2023/9"
+)
+
+// Solution of a single puzzle part. Reads the whole puzzle input from r
+// and returns the answer.
+type Solver func(r io.Reader) (string, error)
+
+type Day struct {
+	Number int
+	Parts  []Solver // Parts[0] is part 1, Parts[1] is part 2
+	// Day specific command run by "aoc day<N> [flags]", nil if the day has none.
+	Command func(args []string) error
+}
+
+// Registry of all implemented days, ordered by day number.
+var days = []Day{
+	{Number: 1, Parts: []Solver{day1.Part1, day1.Part2}, Command: day1Command},
+	{Number: 2, Parts: []Solver{day2.Part1, day2.Part2}, Command: day2Command},
+	{Number: 3, Parts: []Solver{day3.Part1, day3.Part2}, Command: day3Command},
+	{Number: 4, Parts: []Solver{day4.Part1, day4.Part2}, Command: day4Command},
+	{Number: 5, Parts: []Solver{day5.Part1, day5.Part2}},
+	{Number: 6, Parts: []Solver{day6.Part1, day6.Part2}},
+	{Number: 7, Parts: []Solver{day7.Part1, day7.Part2}},
+	{Number: 8, Parts: []Solver{day8.Part1, day8.Part2}},
+	{Number: 9, Parts: []Solver{day9.Part1, day9.Part2}},
+	{Number: 10, Parts: []Solver{day10.Part1}}, // part 2 not implemented
+}
+
+// Returns the registered day with the given number.
+func FindDay(number int) (Day, bool) {
+	for _, day := range days {
+		if day.Number == number {
+			return day, true
+		}
+	}
+
+	return Day{}, false
+}
+
+// Returns the day of a day specific command name, e.g. "day1".
+func FindDayCommand(name string) (Day, bool) {
+	number, err := strconv.Atoi(strings.TrimPrefix(name, "day"))
+	if !strings.HasPrefix(name, "day") || err != nil {
+		return Day{}, false
+	}
+	day, ok := FindDay(number)
+	if !ok || day.Command == nil {
+		return Day{}, false
+	}
+
+	return day, true
+}
+
+// Returns the solver of a part (1-based) or nil if the part is not implemented.
+func (d Day) Part(part int) Solver {
+	if part < 1 || part > len(d.Parts) {
+		return nil
+	}
+
+	return d.Parts[part-1]
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/prki/aoc2023/lib/input"
+)
+
+// Single puzzle part to be solved.
+type Task struct {
+	Day  Day
+	Part int
+}
+
+// Selects the given day or all days in case dayNumber is zero.
+func SelectDays(dayNumber int) ([]Day, error) {
+	if dayNumber == 0 {
+		return days, nil
+	}
+
+	day, ok := FindDay(dayNumber)
+	if !ok {
+		return nil, fmt.Errorf("day %d is not implemented", dayNumber)
+	}
+
+	return []Day{day}, nil
+}
+
+// Selects tasks for the given day and part. Zero selects all days or parts.
+// Parts which are not implemented are skipped unless explicitly requested.
+func SelectTasks(dayNumber, part int) ([]Task, error) {
+	selectedDays, err := SelectDays(dayNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []Task
+	for _, day := range selectedDays {
+		if part == 0 {
+			for p := 1; p <= len(day.Parts); p++ {
+				ret = append(ret, Task{Day: day, Part: p})
+			}
+			continue
+		}
+		if day.Part(part) == nil {
+			return nil, fmt.Errorf("day %d part %d is not implemented", day.Number, part)
+		}
+		ret = append(ret, Task{Day: day, Part: part})
+	}
+
+	return ret, nil
+}
+
+// Directory of a day, relative to the repository root dir.
+func DayDir(dir string, dayNumber int) string {
+	return filepath.Join(dir, strconv.Itoa(dayNumber))
+}
+
+// Default puzzle input of a day, relative to the repository root dir.
+func DefaultInputPath(dir string, dayNumber int) string {
+	return filepath.Join(DayDir(dir, dayNumber), "input.txt")
+}
+
+// Reads the whole input on path (file or stdin), so that it can be passed
+// to several parts of the same day.
+func loadInput(path string) ([]byte, error) {
+	fil, err := input.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fil.Close()
+
+	ret, err := io.ReadAll(fil)
+	if err != nil {
+		return nil, fmt.Errorf("can't read input %s: %w", path, err)
+	}
+
+	return ret, nil
+}
+
+func (t Task) Name() string {
+	return fmt.Sprintf("day%d/part%d", t.Day.Number, t.Part)
+}
+
+// Solves the task with the given input.
+func (t Task) Solve(puzzleInput []byte) (string, error) {
+	answer, err := t.Day.Part(t.Part)(bytes.NewReader(puzzleInput))
+	if err != nil {
+		return "", fmt.Errorf("day %d part %d: %w", t.Day.Number, t.Part, err)
+	}
+
+	return answer, nil
+}
+
+// Puzzle inputs of the selected tasks. Input of each day is read only once,
+// so that it can be shared by both parts.
+type InputCache struct {
+	Dir    string // repository root
+	Path   string // overrides default inputs of all days if set
+	inputs map[int][]byte
+}
+
+func NewInputCache(dir, path string) *InputCache {
+	return &InputCache{
+		Dir:    dir,
+		Path:   path,
+		inputs: make(map[int][]byte),
+	}
+}
+
+// Returns the input path of a day.
+func (c *InputCache) InputPath(dayNumber int) string {
+	if c.Path != "" {
+		return c.Path
+	}
+
+	return DefaultInputPath(c.Dir, dayNumber)
+}
+
+// Returns the input of a day, reading it on first use.
+func (c *InputCache) Get(dayNumber int) ([]byte, error) {
+	puzzleInput, ok := c.inputs[dayNumber]
+	if ok {
+		return puzzleInput, nil
+	}
+
+	puzzleInput, err := loadInput(c.InputPath(dayNumber))
+	if err != nil {
+		return nil, err
+	}
+	c.inputs[dayNumber] = puzzleInput
+
+	return puzzleInput, nil
+}
+
+// Result of a solved task. With -format json, each result is printed as
+// one JSON record per line.
+type RunResult struct {
+	Day      int           `json:"day"`
+	Part     int           `json:"part"`
+	Answer   string        `json:"answer"`
+	Duration time.Duration `json:"duration"` // nanoseconds
+	Input    string        `json:"input"`
+}
+
+// Solves the task, measuring the duration of the solution.
+func (t Task) Run(puzzleInput []byte, inputPath string) (RunResult, error) {
+	ret := RunResult{
+		Day:   t.Day.Number,
+		Part:  t.Part,
+		Input: inputPath,
+	}
+
+	var err error
+	start := time.Now()
+	ret.Answer, err = t.Solve(puzzleInput)
+	ret.Duration = time.Since(start)
+
+	return ret, err
+}
+
+func runCommand(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	dayNumber := fs.Int("day", 0, "day to run, 0 runs all days")
+	part := fs.Int("part", 0, "part to run, 0 runs all parts")
+	inputPath := fs.String("input", "", "puzzle input, \"-\" reads stdin (default <dir>/<day>/input.txt)")
+	dir := fs.String("dir", ".", "repository root containing the day directories")
+	format := fs.String("format", "text", "output format, text or json")
+	setupLogging := addLoggingFlags(fs)
+	fs.Parse(args)
+
+	if err := setupLogging(); err != nil {
+		return err
+	}
+
+	if *inputPath != "" && *dayNumber == 0 {
+		return errors.New("-input can only be used together with -day")
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	tasks, err := SelectTasks(*dayNumber, *part)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	inputs := NewInputCache(*dir, *inputPath)
+	for _, task := range tasks {
+		puzzleInput, err := inputs.Get(task.Day.Number)
+		if err != nil {
+			return err
+		}
+
+		res, err := task.Run(puzzleInput, inputs.InputPath(task.Day.Number))
+		if err != nil {
+			return err
+		}
+		if *format == "json" {
+			if err := enc.Encode(res); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "Day %d part %d: %s\n", res.Day, res.Part, res.Answer)
+	}
+
+	return nil
+}
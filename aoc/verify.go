@@ -0,0 +1,107 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/prki/aoc2023/lib/answers"
+)
+
+// Known answer of a task on a single input.
+type Check struct {
+	Task
+	InputPath string
+	Expected  string
+}
+
+func (c Check) Name() string {
+	return c.Task.Name() + "/" + filepath.Base(c.InputPath)
+}
+
+// Solves the task of the check on its input and returns the actual answer.
+func (c Check) Run() (string, error) {
+	puzzleInput, err := loadInput(c.InputPath)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := c.Task.Run(puzzleInput, c.InputPath)
+
+	return res.Answer, err
+}
+
+// Collects checks of all answers in the manifests of the selected days.
+// Days without a manifest have no checks.
+func CollectChecks(dir string, selectedDays []Day) ([]Check, error) {
+	var ret []Check
+
+	for _, day := range selectedDays {
+		dayDir := DayDir(dir, day.Number)
+		manifest, err := answers.Load(dayDir)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, inputName := range manifest.Inputs() {
+			for part := 1; part <= len(day.Parts); part++ {
+				expected, ok := manifest[inputName].Part(part)
+				if !ok {
+					continue
+				}
+				ret = append(ret, Check{
+					Task:      Task{Day: day, Part: part},
+					InputPath: filepath.Join(dayDir, inputName),
+					Expected:  expected,
+				})
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+func verifyCommand(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	dayNumber := fs.Int("day", 0, "day to verify, 0 verifies all days")
+	dir := fs.String("dir", ".", "repository root containing the day directories")
+	setupLogging := addLoggingFlags(fs)
+	fs.Parse(args)
+
+	if err := setupLogging(); err != nil {
+		return err
+	}
+
+	selectedDays, err := SelectDays(*dayNumber)
+	if err != nil {
+		return err
+	}
+	checks, err := CollectChecks(*dir, selectedDays)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, check := range checks {
+		actual, err := check.Run()
+		if err != nil {
+			failed += 1
+			fmt.Fprintf(os.Stdout, "FAIL %s: %v\n", check.Name(), err)
+			continue
+		}
+		if actual != check.Expected {
+			failed += 1
+			fmt.Fprintf(os.Stdout, "FAIL %s: expected %s, got %s\n", check.Name(), check.Expected, actual)
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "ok   %s\n", check.Name())
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+	fmt.Fprintf(os.Stdout, "All %d checks passed\n", len(checks))
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Runs every registered part against every input with a known answer.
+func TestAnswers(t *testing.T) {
+	checks, err := CollectChecks("..", days)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for _, check := range checks {
+		check := check
+		t.Run(check.Name(), func(t *testing.T) {
+			actual, err := check.Run()
+			assert.NoError(t, err)
+			assert.Equal(t, check.Expected, actual)
+		})
+	}
+}
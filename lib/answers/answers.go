@@ -0,0 +1,83 @@
+// Package answers loads manifests of known-good puzzle answers.
+//
+// Each day keeps an answers.toml next to its inputs, with one table per
+// input file:
+//
+//	["input_mini.txt"]
+//	part1 = "35"
+//	part2 = "46"
+//
+// Parts without a known answer are left out.
+package answers
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Name of the manifest file in the directory of each day.
+const FileName = "answers.toml"
+
+// Known answers of a single input.
+type Answers struct {
+	Part1 string `toml:"part1"`
+	Part2 string `toml:"part2"`
+}
+
+// Returns the answer of a part (1-based) and whether it is known.
+func (a Answers) Part(part int) (string, bool) {
+	var ret string
+	switch part {
+	case 1:
+		ret = a.Part1
+	case 2:
+		ret = a.Part2
+	}
+
+	return ret, len(ret) > 0
+}
+
+// Known answers of a single day, keyed by input file name relative to the
+// directory of the manifest.
+type Manifest map[string]Answers
+
+// Input file names of the manifest in a stable order.
+func (m Manifest) Inputs() []string {
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+
+	return ret
+}
+
+// Loads the manifest in dir. A missing manifest is not an error, an empty
+// manifest is returned instead.
+func Load(dir string) (Manifest, error) {
+	ret := make(Manifest)
+
+	path := filepath.Join(dir, FileName)
+	meta, err := toml.DecodeFile(path, &ret)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ret, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("can't load answers %s: %w", path, err)
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("unknown keys in answers %s: %v", path, undecoded)
+	}
+	for _, inputName := range ret.Inputs() {
+		if _, err := os.Stat(filepath.Join(dir, inputName)); err != nil {
+			return nil, fmt.Errorf("answers %s: %w", path, err)
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,14 @@
+module github.com/prki/aoc2023/lib
+
+go 1.21.4
+
+require (
+	github.com/BurntSushi/toml v1.5.0
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,261 @@
+// Package grid provides a generic rectangular 2D grid for puzzles given as
+// maps of characters.
+//
+// Points are signed, so that neighbors of cells on the border can be
+// computed without wrapping around. All accessors are bounds-checked.
+package grid
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/prki/aoc2023/lib/input"
+)
+
+// Point in a grid. X grows to the right (east), Y grows down (south).
+type Point struct {
+	X int
+	Y int
+}
+
+func (p Point) Add(q Point) Point {
+	return Point{X: p.X + q.X, Y: p.Y + q.Y}
+}
+
+func (p Point) Sub(q Point) Point {
+	return Point{X: p.X - q.X, Y: p.Y - q.Y}
+}
+
+var (
+	North = Point{X: 0, Y: -1}
+	East  = Point{X: 1, Y: 0}
+	South = Point{X: 0, Y: 1}
+	West  = Point{X: -1, Y: 0}
+
+	NorthEast = North.Add(East)
+	SouthEast = South.Add(East)
+	SouthWest = South.Add(West)
+	NorthWest = North.Add(West)
+)
+
+// Directions of the 4 orthogonal neighbors, clockwise from north.
+var Dirs4 = []Point{North, East, South, West}
+
+// Directions of the 8 neighbors including diagonal ones, clockwise from north.
+var Dirs8 = []Point{North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest}
+
+// Sequence of grid cells. Iteration stops once yield returns false.
+// The signature matches range-over-func iterators of newer Go versions.
+type Seq[T any] func(yield func(p Point, v T) bool)
+
+// Rectangular grid of cells of type T.
+type Grid[T any] struct {
+	width  int
+	height int
+	cells  []T // row by row
+}
+
+// Returns a grid of the given size with all cells set to the zero value.
+func New[T any](width, height int) *Grid[T] {
+	return &Grid[T]{
+		width:  width,
+		height: height,
+		cells:  make([]T, width*height),
+	}
+}
+
+// Returns a grid of the given size with all cells set to fill.
+func Filled[T any](width, height int, fill T) *Grid[T] {
+	ret := New[T](width, height)
+	for i := range ret.cells {
+		ret.cells[i] = fill
+	}
+
+	return ret
+}
+
+// Converts lines into a grid, cell by cell. All lines must be of the same
+// length in runes, errors are of type input.ErrorList. Trailing empty lines
+// are ignored.
+func Parse[T any](lines []string, cell func(p Point, r rune) (T, error)) (*Grid[T], error) {
+	var errs input.ErrorList
+
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	width := 0
+	if len(lines) > 0 {
+		width = len([]rune(lines[0]))
+	}
+	ret := New[T](width, len(lines))
+
+	for y, line := range lines {
+		x := 0
+		for col, r := range line {
+			if x >= width {
+				break
+			}
+			v, err := cell(Point{X: x, Y: y}, r)
+			if err != nil {
+				errs.AddLine(y+1, line, input.NewLine(line).Slice(col, col+len(string(r))).Errorf("%v", err))
+			}
+			ret.cells[y*width+x] = v
+			x++
+		}
+		if rowWidth := len([]rune(line)); rowWidth != width {
+			errs.Add(&input.ParseError{
+				Line:   y + 1,
+				Input:  line,
+				Reason: fmt.Sprintf("row is %d cells wide, expected %d", rowWidth, width),
+			})
+		}
+	}
+
+	return ret, errs.Err()
+}
+
+// Converts lines into a grid of runes, see Parse.
+func ParseRunes(lines []string) (*Grid[rune], error) {
+	return Parse(lines, func(_ Point, r rune) (rune, error) { return r, nil })
+}
+
+func (g *Grid[T]) Width() int {
+	return g.width
+}
+
+func (g *Grid[T]) Height() int {
+	return g.height
+}
+
+func (g *Grid[T]) InBounds(p Point) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+// Returns the cell on p. Out of bounds, the zero value and false is returned.
+func (g *Grid[T]) At(p Point) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+
+	return g.cells[p.Y*g.width+p.X], true
+}
+
+// Returns the cell on p or the zero value if p is out of bounds.
+func (g *Grid[T]) Get(p Point) T {
+	ret, _ := g.At(p)
+
+	return ret
+}
+
+// Sets the cell on p. Returns false, leaving the grid unchanged, if p is out
+// of bounds.
+func (g *Grid[T]) Set(p Point, v T) bool {
+	if !g.InBounds(p) {
+		return false
+	}
+	g.cells[p.Y*g.width+p.X] = v
+
+	return true
+}
+
+// Returns cells of p moved in each direction, skipping those out of bounds.
+func (g *Grid[T]) neighbors(p Point, dirs []Point) Seq[T] {
+	return func(yield func(Point, T) bool) {
+		for _, dir := range dirs {
+			q := p.Add(dir)
+			if v, ok := g.At(q); ok && !yield(q, v) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the orthogonal neighbors of p within the grid.
+func (g *Grid[T]) Neighbors4(p Point) Seq[T] {
+	return g.neighbors(p, Dirs4)
+}
+
+// Returns the orthogonal and diagonal neighbors of p within the grid.
+func (g *Grid[T]) Neighbors8(p Point) Seq[T] {
+	return g.neighbors(p, Dirs8)
+}
+
+// Returns cells of the rectangle from min to max, both inclusive, row by
+// row. The rectangle is clipped to the grid.
+func (g *Grid[T]) Region(min, max Point) Seq[T] {
+	min.X, min.Y = clamp(min.X, 0, g.width), clamp(min.Y, 0, g.height)
+	max.X, max.Y = clamp(max.X, -1, g.width-1), clamp(max.Y, -1, g.height-1)
+
+	return func(yield func(Point, T) bool) {
+		for y := min.Y; y <= max.Y; y++ {
+			for x := min.X; x <= max.X; x++ {
+				if !yield(Point{X: x, Y: y}, g.cells[y*g.width+x]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func clamp(v, low, high int) int {
+	if v < low {
+		return low
+	} else if v > high {
+		return high
+	}
+
+	return v
+}
+
+// Returns all cells, row by row.
+func (g *Grid[T]) All() Seq[T] {
+	return g.Region(Point{}, Point{X: g.width - 1, Y: g.height - 1})
+}
+
+// Returns cells of row y, empty if y is out of bounds.
+func (g *Grid[T]) Row(y int) Seq[T] {
+	return g.Region(Point{X: 0, Y: y}, Point{X: g.width - 1, Y: y})
+}
+
+// Returns cells of column x, empty if x is out of bounds.
+func (g *Grid[T]) Column(x int) Seq[T] {
+	return g.Region(Point{X: x, Y: 0}, Point{X: x, Y: g.height - 1})
+}
+
+// Writes the grid row by row, formatting each cell by cell.
+func (g *Grid[T]) Print(w io.Writer, cell func(p Point, v T) string) error {
+	var row strings.Builder
+	for y := 0; y < g.height; y++ {
+		row.Reset()
+		g.Row(y)(func(p Point, v T) bool {
+			row.WriteString(cell(p, v))
+			return true
+		})
+		row.WriteByte('\n')
+		if _, err := io.WriteString(w, row.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns the grid as lines of text. Runes and bytes are written as
+// characters, other cells as formatted by fmt.
+func (g *Grid[T]) String() string {
+	var ret strings.Builder
+	g.Print(&ret, func(_ Point, v T) string {
+		switch v := any(v).(type) {
+		case rune:
+			return string(v)
+		case byte:
+			return string(rune(v))
+		}
+		return fmt.Sprint(v)
+	})
+
+	return ret.String()
+}
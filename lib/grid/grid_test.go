@@ -0,0 +1,55 @@
+package grid
+
+import (
+	"testing"
+
+	"github.com/prki/aoc2023/lib/input"
+	"github.com/stretchr/testify/assert"
+)
+
+func collect[T any](seq Seq[T]) []Point {
+	var ret []Point
+	seq(func(p Point, _ T) bool {
+		ret = append(ret, p)
+		return true
+	})
+
+	return ret
+}
+
+func TestParse(t *testing.T) {
+	g, err := ParseRunes([]string{"467.", "..*.", ""})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, g.Width())
+	assert.Equal(t, 2, g.Height())
+	assert.Equal(t, '*', g.Get(Point{X: 2, Y: 1}))
+	assert.Equal(t, "467.\n..*.\n", g.String())
+
+	_, err = ParseRunes([]string{"467.", "..*"})
+	var errs input.ErrorList
+	assert.ErrorAs(t, err, &errs)
+	assert.EqualError(t, errs[0], `line 2: row is 3 cells wide, expected 4 in "..*"`)
+}
+
+func TestBounds(t *testing.T) {
+	g := New[int](3, 2)
+	assert.True(t, g.Set(Point{X: 2, Y: 1}, 5))
+	assert.False(t, g.Set(Point{X: 3, Y: 1}, 5))
+
+	v, ok := g.At(Point{X: 2, Y: 1})
+	assert.True(t, ok)
+	assert.Equal(t, 5, v)
+	_, ok = g.At(Point{X: -1, Y: 0})
+	assert.False(t, ok)
+}
+
+func TestIterators(t *testing.T) {
+	g := New[int](3, 3)
+	assert.Equal(t, []Point{{X: 1, Y: 0}, {X: 0, Y: 1}}, collect(g.Neighbors4(Point{})))
+	assert.Len(t, collect(g.Neighbors8(Point{X: 1, Y: 1})), 8)
+	assert.Equal(t, []Point{{X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}}, collect(g.Row(2)))
+	assert.Equal(t, []Point{{X: 1, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 2}}, collect(g.Column(1)))
+	assert.Equal(t, []Point{{X: 2, Y: 1}, {X: 2, Y: 2}}, collect(g.Region(Point{X: 2, Y: 1}, Point{X: 5, Y: 5})))
+	assert.Empty(t, collect(g.Row(3)))
+	assert.Len(t, collect(g.All()), 9)
+}
@@ -0,0 +1,98 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Problem found at a position of the puzzle input.
+type ParseError struct {
+	Line   int    // 1-based line number, 0 if unknown
+	Column int    // 1-based byte column in the line, 0 if unknown
+	Input  string // whole offending line
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	var sb strings.Builder
+	if e.Line > 0 {
+		fmt.Fprintf(&sb, "line %d", e.Line)
+		if e.Column > 0 {
+			fmt.Fprintf(&sb, ", column %d", e.Column)
+		}
+		sb.WriteString(": ")
+	} else if e.Column > 0 {
+		fmt.Fprintf(&sb, "column %d: ", e.Column)
+	}
+	sb.WriteString(e.Reason)
+	if len(e.Input) > 0 {
+		fmt.Fprintf(&sb, " in %q", e.Input)
+	}
+
+	return sb.String()
+}
+
+// All parse errors of a single input. Parsers collect errors into the list
+// and keep going, so that a malformed input is reported at once.
+type ErrorList []*ParseError
+
+func (l *ErrorList) Add(err *ParseError) {
+	*l = append(*l, err)
+}
+
+// Adds err found on line. Line numbers of parse errors are set, other errors
+// are converted into a parse error of the whole line.
+func (l *ErrorList) AddLine(line int, input string, err error) {
+	var list ErrorList
+	var perr *ParseError
+	if errors.As(err, &list) {
+		for _, e := range list {
+			e.Line = line
+			l.Add(e)
+		}
+	} else if errors.As(err, &perr) {
+		perr.Line = line
+		l.Add(perr)
+	} else {
+		l.Add(&ParseError{Line: line, Input: input, Reason: err.Error()})
+	}
+}
+
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	}
+
+	msgs := make([]string, 0, len(l)+1)
+	msgs = append(msgs, fmt.Sprintf("%d parse errors:", len(l)))
+	for _, err := range l {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "\n\t")
+}
+
+// Orders the errors by line and column, keeping the order of errors at the
+// same position.
+func (l ErrorList) Sort() {
+	sort.SliceStable(l, func(i, j int) bool {
+		if l[i].Line != l[j].Line {
+			return l[i].Line < l[j].Line
+		}
+		return l[i].Column < l[j].Column
+	})
+}
+
+// Returns nil if the list is empty, the list itself otherwise.
+func (l ErrorList) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+
+	return l
+}
@@ -0,0 +1,83 @@
+package input
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Part of an input line together with its position in the line.
+// Parsers split lines into fields so that errors can point to the exact
+// column of the offending token.
+type Field struct {
+	Text   string
+	Column int    // 1-based byte column of Text in Input
+	Input  string // whole line the field was taken from
+}
+
+// Returns a field spanning the whole line.
+func NewLine(line string) Field {
+	return Field{Text: line, Column: 1, Input: line}
+}
+
+// Returns the field of f.Text[start:end].
+func (f Field) Slice(start, end int) Field {
+	return Field{Text: f.Text[start:end], Column: f.Column + start, Input: f.Input}
+}
+
+// Same as strings.Split, keeping track of columns.
+func (f Field) Split(sep string) []Field {
+	var ret []Field
+
+	start := 0
+	for {
+		idx := strings.Index(f.Text[start:], sep)
+		if idx < 0 || len(sep) == 0 {
+			ret = append(ret, f.Slice(start, len(f.Text)))
+			return ret
+		}
+		ret = append(ret, f.Slice(start, start+idx))
+		start += idx + len(sep)
+	}
+}
+
+// Same as strings.Fields, keeping track of columns.
+func (f Field) Fields() []Field {
+	var ret []Field
+
+	start := -1
+	for i, r := range f.Text {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				ret = append(ret, f.Slice(start, i))
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		ret = append(ret, f.Slice(start, len(f.Text)))
+	}
+
+	return ret
+}
+
+// Same as strings.TrimSpace, keeping track of columns.
+func (f Field) TrimSpace() Field {
+	trimmedLeft := strings.TrimLeftFunc(f.Text, unicode.IsSpace)
+	start := len(f.Text) - len(trimmedLeft)
+	end := start + len(strings.TrimRightFunc(trimmedLeft, unicode.IsSpace))
+
+	return f.Slice(start, end)
+}
+
+// Returns a parse error pointing to the field. Line of the error is left
+// unset, see ErrorList.AddLine.
+func (f Field) Errorf(format string, args ...any) *ParseError {
+	return &ParseError{
+		Column: f.Column,
+		Input:  f.Input,
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,121 @@
+// Package input contains helpers for reading puzzle inputs which are shared
+// by all days.
+//
+// Inputs are read line by line. Unlike a plain bufio.Scanner, lines are not
+// limited by the default 64KB token size and scanner errors are surfaced to
+// the caller instead of being silently dropped.
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// Path which, when passed to Open or ReadInput, reads from stdin instead of a file.
+const Stdin = "-"
+
+// Initial buffer size of the scanner. The buffer grows as needed.
+const initialBufferSize = 64 * 1024
+
+// Returns a line scanner without the default limit on line length.
+// Trailing "\r" is stripped from lines, so CRLF inputs are handled as well.
+func NewScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, initialBufferSize), math.MaxInt)
+
+	return scanner
+}
+
+// Opens the input on path. In case path is Stdin, stdin is returned
+// and closing it is a no-op.
+func Open(path string) (io.ReadCloser, error) {
+	if path == Stdin {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	fil, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't open input %s: %w", path, err)
+	}
+
+	return fil, nil
+}
+
+// Reads all lines from r.
+func ReadLines(r io.Reader) ([]string, error) {
+	ret := make([]string, 0)
+
+	scanner := NewScanner(r)
+	for scanner.Scan() {
+		ret = append(ret, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading input: %w", err)
+	}
+
+	return ret, nil
+}
+
+// Reads all lines of the input on path (file or Stdin).
+func ReadInput(path string) ([]string, error) {
+	fil, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fil.Close()
+
+	ret, err := ReadLines(fil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return ret, nil
+}
+
+// Paragraph of consecutive non-blank input lines.
+type Block struct {
+	Line  int // 1-based line number of the first line of the block
+	Lines []string
+}
+
+// Splits lines into paragraphs separated by one or more blank (or whitespace
+// only) lines.
+// Blank lines are not part of any block and empty blocks are never returned,
+// e.g. leading or trailing blank lines are ignored.
+func Blocks(lines []string) []Block {
+	var ret []Block
+	var curr Block
+
+	for i, line := range lines {
+		if len(strings.TrimSpace(line)) == 0 {
+			if len(curr.Lines) > 0 {
+				ret = append(ret, curr)
+				curr = Block{}
+			}
+			continue
+		}
+		if len(curr.Lines) == 0 {
+			curr.Line = i + 1
+		}
+		curr.Lines = append(curr.Lines, line)
+	}
+	if len(curr.Lines) > 0 {
+		ret = append(ret, curr)
+	}
+
+	return ret
+}
+
+// Reads all lines from r and splits them into blocks. See Blocks.
+func ReadBlocks(r io.Reader) ([]Block, error) {
+	lines, err := ReadLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return Blocks(lines), nil
+}
@@ -0,0 +1,53 @@
+package input
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadLinesLongLine(t *testing.T) {
+	long := strings.Repeat("x", 256*1024)
+	lines, err := ReadLines(strings.NewReader("a\r\n" + long + "\nb"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", long, "b"}, lines)
+}
+
+func TestBlocks(t *testing.T) {
+	lines := []string{"", "LR", "", "", "AAA = (BBB, BBB)", "BBB = (AAA, ZZZ)", ""}
+	expected := []Block{
+		{Line: 2, Lines: []string{"LR"}},
+		{Line: 5, Lines: []string{"AAA = (BBB, BBB)", "BBB = (AAA, ZZZ)"}},
+	}
+	assert.Equal(t, expected, Blocks(lines))
+}
+
+func TestFieldColumns(t *testing.T) {
+	fields := NewLine("Game 5: 2 red,  3 blue").Split(":")[1].Split(",")[1].Fields()
+	assert.Equal(t, []Field{
+		{Text: "3", Column: 17, Input: "Game 5: 2 red,  3 blue"},
+		{Text: "blue", Column: 19, Input: "Game 5: 2 red,  3 blue"},
+	}, fields)
+}
+
+func TestErrorList(t *testing.T) {
+	var errs ErrorList
+	assert.NoError(t, errs.Err())
+
+	line := NewLine("1 x 3")
+	errs.AddLine(4, line.Input, line.Fields()[1].Errorf("invalid number %q", "x"))
+	assert.EqualError(t, errs.Err(), `line 4, column 3: invalid number "x" in "1 x 3"`)
+}
+
+func TestErrorListSort(t *testing.T) {
+	errs := ErrorList{
+		{Line: 2, Column: 1, Reason: "c"},
+		{Line: 1, Column: 5, Reason: "b"},
+		{Line: 1, Column: 1, Reason: "a"},
+	}
+	errs.Sort()
+	assert.Equal(t, "a", errs[0].Reason)
+	assert.Equal(t, "b", errs[1].Reason)
+	assert.Equal(t, "c", errs[2].Reason)
+}
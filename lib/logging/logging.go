@@ -0,0 +1,193 @@
+// Package logging provides leveled structured loggers of the days, built on
+// log/slog.
+//
+// Every day logs through its own logger returned by Day. Output and levels
+// of all day loggers are set at once by Configure, so that e.g. day 5 can be
+// traced while other days only report warnings:
+//
+//	AOC_LOG="warn,5=trace" aoc run
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// Level for very detailed output, e.g. every step of a search.
+	LevelTrace = slog.LevelDebug - 4
+	// Level disabling all output.
+	LevelOff = slog.Level(math.MaxInt32)
+)
+
+// Environment variable with the levels specification, see ParseLevels.
+const EnvVar = "AOC_LOG"
+
+// Log levels of all days. Days without an explicit level use Default.
+type Levels struct {
+	Default slog.Level
+	Days    map[int]slog.Level
+}
+
+func (l Levels) Day(day int) slog.Level {
+	level, ok := l.Days[day]
+	if !ok {
+		return l.Default
+	}
+
+	return level
+}
+
+// Parses a level name. Besides the slog level names (e.g. "debug", "warn+2"),
+// "trace" and "off" are accepted.
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "trace":
+		return LevelTrace, nil
+	case "off":
+		return LevelOff, nil
+	}
+
+	var ret slog.Level
+	if err := ret.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return ret, err
+	}
+
+	return ret, nil
+}
+
+// Parses a comma separated levels specification. Each item is either a level,
+// which sets the default level, or "<day>=<level>", which sets the level of
+// a single day, e.g. "warn,5=trace,10=debug". Levels not set by the
+// specification are taken from base.
+func ParseLevels(spec string, base Levels) (Levels, error) {
+	ret := Levels{
+		Default: base.Default,
+		Days:    make(map[int]slog.Level),
+	}
+	for day, level := range base.Days {
+		ret.Days[day] = level
+	}
+
+	for _, item := range strings.Split(spec, ",") {
+		if len(strings.TrimSpace(item)) == 0 {
+			continue
+		}
+
+		dayStr, levelStr, isDay := strings.Cut(item, "=")
+		if !isDay {
+			level, err := ParseLevel(item)
+			if err != nil {
+				return ret, fmt.Errorf("invalid log level %q: %w", item, err)
+			}
+			ret.Default = level
+			continue
+		}
+
+		day, err := strconv.Atoi(strings.TrimSpace(dayStr))
+		if err != nil {
+			return ret, fmt.Errorf("invalid day in log level %q", item)
+		}
+		level, err := ParseLevel(levelStr)
+		if err != nil {
+			return ret, fmt.Errorf("invalid log level %q: %w", item, err)
+		}
+		ret.Days[day] = level
+	}
+
+	return ret, nil
+}
+
+type config struct {
+	levels  Levels
+	handler slog.Handler
+}
+
+var current atomic.Pointer[config]
+
+func init() {
+	Configure(os.Stderr, Levels{Default: slog.LevelInfo})
+}
+
+func replaceLevelName(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.LevelKey && len(groups) == 0 {
+		if level, ok := a.Value.Any().(slog.Level); ok && level == LevelTrace {
+			a.Value = slog.StringValue("TRACE")
+		}
+	}
+
+	return a
+}
+
+// Sets the output and the levels of all day loggers.
+func Configure(w io.Writer, levels Levels) {
+	handler := slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level:       LevelTrace, // filtered by dayHandler
+		ReplaceAttr: replaceLevelName,
+	})
+	current.Store(&config{
+		levels:  levels,
+		handler: handler,
+	})
+}
+
+// Disables output of all day loggers.
+func Disable() {
+	Configure(io.Discard, Levels{Default: LevelOff})
+}
+
+// Logs msg at LevelTrace.
+func Trace(l *slog.Logger, msg string, args ...any) {
+	l.Log(context.Background(), LevelTrace, msg, args...)
+}
+
+// Returns the logger of a day. Records carry the "day" attribute and follow
+// the configuration set by Configure, even if it changes after the logger
+// was created.
+func Day(day int) *slog.Logger {
+	return slog.New(&dayHandler{day: day}).With("day", day)
+}
+
+// Handler deferring to the current configuration on every record.
+// Attributes and groups added to the logger are replayed onto the
+// configured handler in their original order.
+type dayHandler struct {
+	day int
+	ops []func(slog.Handler) slog.Handler
+}
+
+func (h *dayHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= current.Load().levels.Day(h.day)
+}
+
+func (h *dayHandler) Handle(ctx context.Context, r slog.Record) error {
+	handler := current.Load().handler
+	for _, op := range h.ops {
+		handler = op(handler)
+	}
+
+	return handler.Handle(ctx, r)
+}
+
+func (h *dayHandler) with(op func(slog.Handler) slog.Handler) *dayHandler {
+	ops := make([]func(slog.Handler) slog.Handler, 0, len(h.ops)+1)
+	ops = append(ops, h.ops...)
+	ops = append(ops, op)
+
+	return &dayHandler{day: h.day, ops: ops}
+}
+
+func (h *dayHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+func (h *dayHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
@@ -0,0 +1,36 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLevels(t *testing.T) {
+	levels, err := ParseLevels("warn, 5=trace,10=debug", Levels{Default: slog.LevelInfo})
+	assert.NoError(t, err)
+	assert.Equal(t, slog.LevelWarn, levels.Day(1))
+	assert.Equal(t, LevelTrace, levels.Day(5))
+	assert.Equal(t, slog.LevelDebug, levels.Day(10))
+
+	_, err = ParseLevels("x=debug", Levels{})
+	assert.Error(t, err)
+	_, err = ParseLevels("loud", Levels{})
+	assert.Error(t, err)
+}
+
+func TestDayLevels(t *testing.T) {
+	defer Configure(os.Stderr, Levels{Default: slog.LevelInfo})
+
+	var buf bytes.Buffer
+	Configure(&buf, Levels{Default: slog.LevelWarn, Days: map[int]slog.Level{5: LevelTrace}})
+
+	Day(1).Info("hidden")
+	Day(5).With("seed", 79).Log(context.Background(), LevelTrace, "mapped")
+	assert.Contains(t, buf.String(), "level=TRACE msg=mapped day=5 seed=79")
+	assert.NotContains(t, buf.String(), "hidden")
+}